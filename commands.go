@@ -0,0 +1,731 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/go-helpers/settings"
+	"github.com/senzing-garage/go-helpers/settingsparser"
+)
+
+// Format of each "settings explain" line.
+const explainFormat = "%-23s%s\n"
+
+// Values of the --arrays option of "json flatten" and "json unflatten".
+var flattenArrays = map[string]jsonutil.FlattenArrays{
+	"bracketed": jsonutil.FlattenArraysBracketed,
+	"indexed":   jsonutil.FlattenArraysIndexed,
+	"keep":      jsonutil.FlattenArraysKeep,
+}
+
+// Values of the --case option of "json rename-keys".
+var keyCases = map[string]jsonutil.KeyCase{
+	"camel":       jsonutil.KeyCaseCamel,
+	"kebab":       jsonutil.KeyCaseKebab,
+	"lower-snake": jsonutil.KeyCaseLowerSnake,
+	"pascal":      jsonutil.KeyCasePascal,
+	"upper-snake": jsonutil.KeyCaseUpperSnake,
+}
+
+// Map from "settings build" options to settings.BuildSimpleSettingsUsingMap() keys.
+var settingsBuildOptions = map[string]string{
+	"config-path":           "configPath",
+	"database-url":          "databaseURL",
+	"license-string-base64": "licenseStringBase64",
+	"resource-path":         "resourcePath",
+	"senzing-directory":     "senzingDirectory",
+	"support-path":          "supportPath",
+}
+
+// ----------------------------------------------------------------------------
+// db commands
+// ----------------------------------------------------------------------------
+
+func dbURIToURL(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	return dbConvert(ctx, args, stdin, stdout, settings.BuildSenzingDatabaseURL)
+}
+
+func dbURLToURI(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	return dbConvert(ctx, args, stdin, stdout, settings.BuildSenzingDatabaseURI)
+}
+
+func dbConvert(
+	ctx context.Context,
+	args []string,
+	stdin io.Reader,
+	stdout io.Writer,
+	convert func(string) (string, error),
+) error {
+	inputs, err := readInputs(args, stdin)
+	if err != nil {
+		return err
+	}
+
+	for _, input := range inputs {
+		err = ctx.Err()
+		if err != nil {
+			return fmt.Errorf("db: %w", err)
+		}
+
+		result, err := convert(strings.TrimSpace(input))
+		if err != nil {
+			return fmt.Errorf("db: %w", err)
+		}
+
+		fmt.Fprintln(stdout, result)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// json commands
+// ----------------------------------------------------------------------------
+
+func jsonCanonical(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	flagSet := newFlagSet("json canonical")
+	hash := flagSet.Bool("hash", false, "Print the SHA-256 hash of the canonical JSON instead.")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	if *hash {
+		return jsonTransform(ctx, flagSet.Args(), stdin, stdout, jsonutil.CanonicalHash)
+	}
+
+	return jsonTransform(ctx, flagSet.Args(), stdin, stdout, jsonutil.Canonicalize)
+}
+
+func jsonFlatten(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var options jsonutil.FlattenOptions
+
+	flagSet := jsonFlattenFlagSet("json flatten", &options)
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	return jsonTransform(ctx, flagSet.Args(), stdin, stdout, func(jsonText string) (string, error) {
+		return jsonutil.FlattenObject(jsonText, options)
+	})
+}
+
+// Flags shared by "json flatten" and "json unflatten".
+func jsonFlattenFlagSet(name string, options *jsonutil.FlattenOptions) *flag.FlagSet {
+	result := newFlagSet(name)
+	result.StringVar(&options.Separator, "separator", ".", "Separator between the keys of nested values.")
+	result.Func("arrays", "How array elements are keyed: indexed (A.0), bracketed (A[0]), or keep (not flattened).",
+		func(value string) error {
+			arrays, isKnown := flattenArrays[value]
+			if !isKnown {
+				return errUnknownArrays
+			}
+
+			options.Arrays = arrays
+
+			return nil
+		})
+
+	return result
+}
+
+func jsonFormat(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	flagSet := newFlagSet("json format")
+	color := flagSet.Bool("color", false, "Color keys and values with ANSI escape sequences.")
+	indent := flagSet.String("indent", "  ", "Indentation for each level of nesting.")
+	sortKeys := flagSet.Bool("sort-keys", false, "Sort object members by key.")
+	width := flagSet.Int("width", 0, "Write arrays that fit within this many columns on one line.")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	options := jsonutil.FormatOptions{
+		Color:    *color,
+		Indent:   *indent,
+		MaxWidth: *width,
+		Prefix:   "",
+		SortKeys: *sortKeys,
+	}
+
+	return jsonTransform(ctx, flagSet.Args(), stdin, stdout, func(jsonText string) (string, error) {
+		return jsonutil.Format(jsonText, options)
+	})
+}
+
+func jsonFromTOML(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	return jsonTransform(ctx, args, stdin, stdout, jsonutil.TOMLToJSON)
+}
+
+func jsonFromYAML(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	return jsonTransform(ctx, args, stdin, stdout, jsonutil.YAMLToJSON)
+}
+
+func jsonNormalize(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	flagSet := newFlagSet("json normalize")
+	lenient := flagSet.Bool("lenient", false, "Accept comments, trailing commas, single quotes, and unquoted keys.")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	if *lenient {
+		return jsonTransform(ctx, flagSet.Args(), stdin, stdout, jsonutil.NormalizeLenient)
+	}
+
+	return jsonTransform(ctx, flagSet.Args(), stdin, stdout, jsonutil.Normalize)
+}
+
+func jsonRedact(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var keys, paths stringList
+
+	flagSet := newFlagSet("json redact")
+	flagSet.Var(&keys, "key", "JSON key whose value is redacted. May be repeated.")
+	flagSet.Var(&paths, "path", "Selector (e.g. $.RECORDS[*].SSN) of a value to redact. May be repeated.")
+	pii := flagSet.Bool("pii", false, "Also replace SSNs, email addresses, phone numbers, etc. found in any value.")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	return jsonTransform(ctx, flagSet.Args(), stdin, stdout, func(jsonText string) (string, error) {
+		redactedJSON, err := jsonutil.Redact(jsonText, keys...)
+		if err != nil {
+			return "", err
+		}
+
+		redactedJSON, err = jsonutil.RedactPaths(redactedJSON, paths...)
+		if err != nil || !*pii {
+			return redactedJSON, err
+		}
+
+		return jsonutil.RedactPII(redactedJSON, nil)
+	})
+}
+
+func jsonRenameKeys(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var renames stringList
+
+	flagSet := newFlagSet("json rename-keys")
+	keyCase := flagSet.String("case", "", "Naming convention: camel, kebab, lower-snake, pascal, or upper-snake.")
+	flagSet.Var(&renames, "rename", "OLD=NEW renames the key OLD to NEW. May be repeated.")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	if *keyCase != "" && len(renames) > 0 {
+		return usageError(fmt.Errorf("%w: --case and --rename", errConflictingOptions))
+	}
+
+	if *keyCase != "" {
+		convention, isKnown := keyCases[*keyCase]
+		if !isKnown {
+			return usageError(fmt.Errorf("%w: %s", errUnknownKeyCase, *keyCase))
+		}
+
+		return jsonTransform(ctx, flagSet.Args(), stdin, stdout, func(jsonText string) (string, error) {
+			return jsonutil.ConvertKeyCase(jsonText, convention)
+		})
+	}
+
+	mapping := map[string]string{}
+
+	for _, rename := range renames {
+		oldKey, newKey, isPair := strings.Cut(rename, "=")
+		if !isPair {
+			return usageError(fmt.Errorf("%w: %s", errBadRename, rename))
+		}
+
+		mapping[oldKey] = newKey
+	}
+
+	return jsonTransform(ctx, flagSet.Args(), stdin, stdout, func(jsonText string) (string, error) {
+		return jsonutil.RenameKeys(jsonText, mapping)
+	})
+}
+
+func jsonSort(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var keys, paths stringList
+
+	flagSet := newFlagSet("json sort")
+	flagSet.Var(&keys, "key", "JSON key whose value orders objects in sorted arrays. May be repeated.")
+	flagSet.Var(&paths, "path", "Selector (e.g. $.RECORDS) of an array to sort. May be repeated. Default: all arrays.")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	options := jsonutil.SortOptions{ByKeys: keys, Paths: paths}
+
+	return jsonTransform(ctx, flagSet.Args(), stdin, stdout, func(jsonText string) (string, error) {
+		return jsonutil.NormalizeAndSortWithOptions(jsonText, options)
+	})
+}
+
+func jsonStrip(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var keys, paths stringList
+
+	flagSet := newFlagSet("json strip")
+	flagSet.Var(&keys, "key", "JSON key to remove. May be repeated.")
+	flagSet.Var(&paths, "path", "Selector (e.g. $.RECORDS[*].SSN) of a value to remove. May be repeated.")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	return jsonTransform(ctx, flagSet.Args(), stdin, stdout, func(jsonText string) (string, error) {
+		strippedJSON, err := jsonutil.Strip(jsonText, keys...)
+		if err != nil {
+			return "", err
+		}
+
+		return jsonutil.StripPaths(strippedJSON, paths...)
+	})
+}
+
+func jsonToTOML(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	return jsonTransform(ctx, args, stdin, stdout, func(jsonText string) (string, error) {
+		tomlText, err := jsonutil.JSONToTOML(jsonText)
+
+		return strings.TrimSuffix(tomlText, "\n"), err
+	})
+}
+
+func jsonToYAML(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	return jsonTransform(ctx, args, stdin, stdout, func(jsonText string) (string, error) {
+		yamlText, err := jsonutil.JSONToYAML(jsonText)
+
+		return strings.TrimSuffix(yamlText, "\n"), err
+	})
+}
+
+func jsonTransform(
+	ctx context.Context,
+	args []string,
+	stdin io.Reader,
+	stdout io.Writer,
+	transform func(string) (string, error),
+) error {
+	inputs, err := readInputs(args, stdin)
+	if err != nil {
+		return err
+	}
+
+	for _, input := range inputs {
+		err = ctx.Err()
+		if err != nil {
+			return fmt.Errorf("json: %w", err)
+		}
+
+		result, err := transform(input)
+		if err != nil {
+			return fmt.Errorf("json: %w", err)
+		}
+
+		fmt.Fprintln(stdout, result)
+	}
+
+	return nil
+}
+
+func jsonTruncate(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var keys stringList
+
+	flagSet := newFlagSet("json truncate")
+	lines := flagSet.Int("lines", 0, "Number of pretty-printed lines to keep. 0 keeps all lines.")
+	flagSet.Var(&keys, "key", "JSON key to remove before truncating. May be repeated.")
+
+	options := jsonutil.TruncateOptions{
+		MaxArrayItems:   0,
+		MaxBytes:        0,
+		MaxDepth:        0,
+		MaxNodes:        0,
+		MaxStringLength: 0,
+	}
+	flagSet.IntVar(&options.MaxArrayItems, "items", 0, "Most elements kept in each array. Output stays valid JSON.")
+	flagSet.IntVar(&options.MaxBytes, "bytes", 0, "Longest output, in bytes. Output stays valid JSON.")
+	flagSet.IntVar(&options.MaxDepth, "depth", 0, "Deepest nesting of arrays and objects kept. Output stays valid JSON.")
+	flagSet.IntVar(&options.MaxNodes, "nodes", 0, "Most values kept. Output stays valid JSON.")
+	flagSet.IntVar(&options.MaxStringLength, "string-length", 0, "Longest string kept. Output stays valid JSON.")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	structured := options.MaxArrayItems > 0 || options.MaxBytes > 0 || options.MaxDepth > 0 ||
+		options.MaxNodes > 0 || options.MaxStringLength > 0
+	if structured && *lines > 0 {
+		return usageError(fmt.Errorf("%w: --lines and --bytes, --depth, --items, --nodes, or --string-length",
+			errConflictingOptions))
+	}
+
+	return jsonTransform(ctx, flagSet.Args(), stdin, stdout, func(jsonText string) (string, error) {
+		if !jsonutil.IsJSON(jsonText) {
+			return "", errNotJSON
+		}
+
+		if structured {
+			strippedJSON, err := jsonutil.Strip(jsonText, keys...)
+			if err != nil {
+				return "", err
+			}
+
+			return jsonutil.TruncateWithOptions(strippedJSON, options)
+		}
+
+		return jsonutil.Truncate(jsonText, *lines, keys...), nil
+	})
+}
+
+func jsonUnflatten(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var options jsonutil.FlattenOptions
+
+	flagSet := jsonFlattenFlagSet("json unflatten", &options)
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	return jsonTransform(ctx, flagSet.Args(), stdin, stdout, func(jsonText string) (string, error) {
+		return jsonutil.Unflatten(jsonText, options)
+	})
+}
+
+func jsonValidate(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	flagSet := newFlagSet("json validate")
+
+	options := jsonutil.ValidateOptions{
+		MaxBytes: 0,
+		MaxDepth: 0,
+	}
+	flagSet.IntVar(&options.MaxBytes, "max-bytes", 0, "Longest JSON accepted, in bytes. 0 accepts any length.")
+	flagSet.IntVar(&options.MaxDepth, "max-depth", jsonutil.DefaultMaxDepth,
+		"Deepest nesting of arrays and objects accepted.")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	return jsonTransform(ctx, flagSet.Args(), stdin, stdout, func(jsonText string) (string, error) {
+		issues := jsonutil.ValidateStrict(jsonText, options)
+		if len(issues) == 0 {
+			return "JSON is valid.", nil
+		}
+
+		descriptions := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			descriptions = append(descriptions, issue.String())
+		}
+
+		return "", fmt.Errorf("%w:\n%s", errNotStrictJSON, strings.Join(descriptions, "\n"))
+	})
+}
+
+// ----------------------------------------------------------------------------
+// record commands
+// ----------------------------------------------------------------------------
+
+func recordFromCSV(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var options record.CSVOptions
+
+	flagSet := recordCSVFlagSet("record from-csv", &options)
+	flagSet.StringVar(&options.DataSource, "data-source", "", "DATA_SOURCE of rows that have none.")
+	flagSet.BoolVar(&options.LazyQuotes, "lazy-quotes", false, "Allow quotes in unquoted fields.")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	return recordConvert(ctx, flagSet.Args(), stdin, stdout, options, record.CSVToJSONLines)
+}
+
+func recordToCSV(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var options record.CSVOptions
+
+	flagSet := recordCSVFlagSet("record to-csv", &options)
+	flagSet.BoolVar(&options.QuoteAll, "quote-all", false, "Quote every field, not only those that need it.")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	return recordConvert(ctx, flagSet.Args(), stdin, stdout, options, record.JSONLinesToCSV)
+}
+
+func recordConvert(
+	ctx context.Context,
+	args []string,
+	stdin io.Reader,
+	stdout io.Writer,
+	options record.CSVOptions,
+	convert func(context.Context, io.Reader, io.Writer, record.CSVOptions) error,
+) error {
+	inputs, err := readInputs(args, stdin)
+	if err != nil {
+		return err
+	}
+
+	for _, input := range inputs {
+		err = convert(ctx, strings.NewReader(input), stdout, options)
+		if err != nil {
+			return fmt.Errorf("record: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Flags shared by "record from-csv" and "record to-csv".
+func recordCSVFlagSet(name string, options *record.CSVOptions) *flag.FlagSet {
+	result := newFlagSet(name)
+	result.Func("delimiter", `Field delimiter (default ","). Use \t for tab.`, func(value string) error {
+		if value == `\t` {
+			value = "\t"
+		}
+
+		if utf8.RuneCountInString(value) != 1 {
+			return errBadDelimiter
+		}
+
+		options.Delimiter, _ = utf8.DecodeRuneInString(value)
+
+		return nil
+	})
+	result.BoolFunc("flatten", "Columns are flattened keys, like RECORDS.0.DATA_SOURCE (see json flatten).",
+		func(value string) error {
+			flatten, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("flatten: %w", err)
+			}
+
+			options.Flatten = nil
+			if flatten {
+				options.Flatten = &jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: "."}
+			}
+
+			return nil
+		})
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// settings commands
+// ----------------------------------------------------------------------------
+
+func settingsBuild(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	_ = stdin
+
+	flagSet := newFlagSet("settings build")
+
+	options := map[string]*string{}
+	for option := range settingsBuildOptions {
+		options[option] = flagSet.String(option, "", "See settings.BuildSimpleSettingsUsingMap().")
+	}
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	if flagSet.NArg() > 0 {
+		return usageError(fmt.Errorf("%w: %s", errUnexpectedArguments, strings.Join(flagSet.Args(), " ")))
+	}
+
+	attributeMap := map[string]string{}
+
+	flagSet.Visit(func(visited *flag.Flag) {
+		attributeMap[settingsBuildOptions[visited.Name]] = *options[visited.Name]
+	})
+
+	result, err := settings.BuildSimpleSettingsUsingMap(ctx, attributeMap)
+	if err != nil {
+		return fmt.Errorf("settings build: %w", err)
+	}
+
+	fmt.Fprintln(stdout, strings.TrimSpace(result))
+
+	return nil
+}
+
+func settingsExplain(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	return forEachSettings(ctx, args, stdin, func(senzingSettings string) error {
+		explanation, err := explainSettings(ctx, senzingSettings)
+		if err != nil {
+			return fmt.Errorf("settings explain: %w", err)
+		}
+
+		fmt.Fprint(stdout, explanation)
+
+		return nil
+	})
+}
+
+func settingsRedact(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	return forEachSettings(ctx, args, stdin, func(senzingSettings string) error {
+		parser, err := settingsparser.New(senzingSettings)
+		if err != nil {
+			return fmt.Errorf("settings redact: %w", err)
+		}
+
+		result, err := parser.RedactedJSON(ctx)
+		if err != nil {
+			return fmt.Errorf("settings redact: %w", err)
+		}
+
+		fmt.Fprintln(stdout, result)
+
+		return nil
+	})
+}
+
+func settingsVerify(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	return forEachSettings(ctx, args, stdin, func(senzingSettings string) error {
+		err := settings.VerifySettings(ctx, senzingSettings)
+		if err != nil {
+			return fmt.Errorf("settings verify: %w", err)
+		}
+
+		fmt.Fprintln(stdout, "Senzing engine configuration is valid.")
+
+		return nil
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func explainSettings(ctx context.Context, senzingSettings string) (string, error) {
+	var result strings.Builder
+
+	parser, err := settingsparser.NewPathSettingsParser(senzingSettings, settingsparser.ParserOptions{Lenient: false})
+	if err != nil {
+		return "", fmt.Errorf("parsing settings: %w", err)
+	}
+
+	generation, err := settings.DetectGeneration(senzingSettings)
+	if err != nil {
+		return "", fmt.Errorf("detecting generation: %w", err)
+	}
+
+	stanzas, err := parser.GetStanzas(ctx)
+	if err != nil {
+		return "", fmt.Errorf("listing stanzas: %w", err)
+	}
+
+	fmt.Fprintf(&result, explainFormat, "Generation:", generation)
+	fmt.Fprintf(&result, explainFormat, "Stanzas:", strings.Join(stanzas, ", "))
+
+	for _, path := range []string{"PIPELINE.CONFIGPATH", "PIPELINE.RESOURCEPATH", "PIPELINE.SUPPORTPATH"} {
+		value, err := parser.GetString(ctx, path, "(not set)")
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", path, err)
+		}
+
+		fmt.Fprintf(&result, explainFormat, path+":", value)
+	}
+
+	license, err := parser.GetLicenseStringBase64(ctx)
+	if err != nil {
+		return "", fmt.Errorf("reading license: %w", err)
+	}
+
+	fmt.Fprintf(&result, explainFormat, "License:", describePresence(len(license) > 0))
+
+	backend, err := parser.GetString(ctx, "SQL.BACKEND", "SQL")
+	if err != nil {
+		return "", fmt.Errorf("reading SQL.BACKEND: %w", err)
+	}
+
+	fmt.Fprintf(&result, explainFormat, "Backend:", backend)
+
+	// Database URIs are listed from the redacted settings, so passwords are not shown.
+
+	redactedSettings, err := parser.RedactedJSON(ctx)
+	if err != nil {
+		return "", fmt.Errorf("redacting settings: %w", err)
+	}
+
+	redactedParser := &settingsparser.BasicSettingsParser{Settings: redactedSettings}
+
+	databaseURIs, err := redactedParser.GetDatabaseURIs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("reading database URIs: %w", err)
+	}
+
+	for _, databaseURI := range databaseURIs {
+		if len(databaseURI) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&result, explainFormat, "Database:", databaseURI)
+	}
+
+	fmt.Fprintf(&result, explainFormat, "Secret references:", describePresence(settings.HasSecretReferences(senzingSettings)))
+
+	return result.String(), nil
+}
+
+func describePresence(present bool) string {
+	if present {
+		return "present"
+	}
+
+	return "none"
+}
+
+/*
+Settings come from inputs, or if there are no inputs, from SENZING_TOOLS_* environment variables.
+*/
+func forEachSettings(ctx context.Context, args []string, stdin io.Reader, handle func(string) error) error {
+	var (
+		err    error
+		inputs []string
+	)
+
+	if len(args) == 0 {
+		senzingSettings, err := settings.BuildSimpleSettingsUsingEnvVars(ctx)
+		if err != nil {
+			return fmt.Errorf("building settings from environment: %w", err)
+		}
+
+		inputs = []string{senzingSettings}
+	} else {
+		inputs, err = readInputs(args, stdin)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, input := range inputs {
+		err = handle(strings.TrimSpace(input))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
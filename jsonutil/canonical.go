@@ -0,0 +1,248 @@
+package jsonutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// Numbers with a decimal exponent from -6 to 20 are written without an exponent (ECMAScript Number.toString).
+const (
+	maxFixedExponent = 21
+	minFixedExponent = -6
+)
+
+const hexDigits = "0123456789abcdef"
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Canonicalize function returns the canonical form of JSON defined by the JSON Canonicalization
+Scheme (JCS, RFC 8785), so that equivalent JSON has the same text in any language with a JCS implementation.
+In the canonical form:
+
+  - There is no whitespace.
+  - Object members are sorted by key, comparing keys as UTF-16 code units.
+  - Strings escape only '"', '\', and control characters; other characters, including non-ASCII, are written as is.
+  - Numbers are IEEE 754 double precision values, written as ECMAScript does (e.g. 1.0 becomes 1, 1e21 stays 1e+21).
+
+Array order is kept.
+Integers larger than 2^53 (e.g. 9007199254740993) cannot be represented exactly and are rounded.
+
+Input
+  - jsonText: The JSON text to be canonicalized.
+
+Output
+  - The canonical JSON text.
+  - An error if the text is not JSON, or a number is too large for a double (e.g. 1e400).
+*/
+func Canonicalize(jsonText string) (string, error) {
+	var result strings.Builder
+
+	document, err := unmarshalValue(jsonText)
+	if err != nil {
+		return jsonText, wraperror.Errorf(err, "Unmarshal")
+	}
+
+	err = writeCanonical(&result, document)
+	if err != nil {
+		return jsonText, wraperror.Error(err)
+	}
+
+	return result.String(), nil
+}
+
+/*
+The CanonicalHash function returns the SHA-256 hash of the canonical form of JSON (see [Canonicalize]),
+as 64 hexadecimal digits.
+Equivalent JSON has the same hash, regardless of whitespace, key order, string escapes, or number format.
+
+As numbers are rounded to doubles, integers larger than 2^53 that differ only in their last digits
+(e.g. the 64-bit ENTITY_IDs 9007199254740992 and 9007199254740993) give the same hash,
+so documents that differ only in such IDs would be taken as duplicates.
+To tell them apart, keep large IDs as strings (e.g. "9007199254740993") before hashing, or compare with [Normalize].
+
+Input
+  - jsonText: The JSON text to be hashed.
+
+Output
+  - The hash.
+  - An error if the text cannot be canonicalized.
+*/
+func CanonicalHash(jsonText string) (string, error) {
+	canonicalJSON, err := Canonicalize(jsonText)
+	if err != nil {
+		return "", wraperror.Error(err)
+	}
+
+	hash := sha256.Sum256([]byte(canonicalJSON))
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Order keys by their UTF-16 code units, as RFC 8785 requires.
+func compareUTF16(key1 string, key2 string) int {
+	return slices.Compare(utf16.Encode([]rune(key1)), utf16.Encode([]rune(key2)))
+}
+
+/*
+Format a number as ECMAScript's Number.prototype.toString does, from the shortest decimal digits
+that round trip to the same double.
+*/
+func formatCanonicalNumber(number json.Number) (string, error) {
+	value, err := strconv.ParseFloat(number.String(), 64)
+	if err != nil || math.IsInf(value, 0) {
+		return "", wraperror.Errorf(errForPackage, "number cannot be canonicalized: %s", number)
+	}
+
+	if value == 0 {
+		return "0", nil // Including -0.
+	}
+
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	// Scientific form, e.g. "1.2345e+06": the digits are "12345" and the value is 0.12345 x 10^7.
+
+	scientific := strconv.FormatFloat(value, 'e', -1, 64)
+	mantissa, exponentText, _ := strings.Cut(scientific, "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+
+	exponent, err := strconv.Atoi(exponentText)
+	if err != nil {
+		return "", wraperror.Errorf(err, "strconv.Atoi")
+	}
+
+	pointPosition := exponent + 1
+
+	switch {
+	case len(digits) <= pointPosition && pointPosition <= maxFixedExponent:
+		return sign + digits + strings.Repeat("0", pointPosition-len(digits)), nil
+	case 0 < pointPosition && pointPosition <= maxFixedExponent:
+		return sign + digits[:pointPosition] + "." + digits[pointPosition:], nil
+	case minFixedExponent < pointPosition && pointPosition <= 0:
+		return sign + "0." + strings.Repeat("0", -pointPosition) + digits, nil
+	}
+
+	result := sign + digits[:1]
+	if len(digits) > 1 {
+		result += "." + digits[1:]
+	}
+
+	if exponent > 0 {
+		return result + "e+" + strconv.Itoa(exponent), nil
+	}
+
+	return result + "e" + strconv.Itoa(exponent), nil
+}
+
+func writeCanonical(builder *strings.Builder, jsonValue any) error {
+	switch typedJSON := jsonValue.(type) {
+	case nil:
+		builder.WriteString(Null)
+	case bool:
+		builder.WriteString(strconv.FormatBool(typedJSON))
+	case string:
+		writeCanonicalString(builder, typedJSON)
+	case json.Number:
+		number, err := formatCanonicalNumber(typedJSON)
+		if err != nil {
+			return err
+		}
+
+		builder.WriteString(number)
+	case []any:
+		builder.WriteByte('[')
+
+		for index, value := range typedJSON {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+
+			err := writeCanonical(builder, value)
+			if err != nil {
+				return err
+			}
+		}
+
+		builder.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(typedJSON))
+		for key := range typedJSON {
+			keys = append(keys, key)
+		}
+
+		slices.SortFunc(keys, compareUTF16)
+
+		builder.WriteByte('{')
+
+		for index, key := range keys {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+
+			writeCanonicalString(builder, key)
+			builder.WriteByte(':')
+
+			err := writeCanonical(builder, typedJSON[key])
+			if err != nil {
+				return err
+			}
+		}
+
+		builder.WriteByte('}')
+	default:
+		return wraperror.Errorf(errForPackage, "unexpected JSON value of type %T", jsonValue)
+	}
+
+	return nil
+}
+
+func writeCanonicalString(builder *strings.Builder, text string) {
+	builder.WriteByte('"')
+
+	for _, character := range text {
+		switch character {
+		case '"':
+			builder.WriteString(`\"`)
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\b':
+			builder.WriteString(`\b`)
+		case '\f':
+			builder.WriteString(`\f`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			if character < ' ' {
+				builder.WriteString(`\u00`)
+				builder.WriteByte(hexDigits[character>>4])
+				builder.WriteByte(hexDigits[character&0xf])
+			} else {
+				builder.WriteRune(character)
+			}
+		}
+	}
+
+	builder.WriteByte('"')
+}
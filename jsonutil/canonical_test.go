@@ -0,0 +1,174 @@
+package jsonutil_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Many test cases are from RFC 8785, sections 3.2.2 and 3.2.3, and Appendix B.
+
+var testCasesForCanonicalize = []struct {
+	name     string
+	jsonText string
+	expected string
+}{
+	{
+		name: "rfc-example",
+		jsonText: `{
+			"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+			"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+			"literals": [null, true, false]
+		}`,
+		expected: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+	},
+	{
+		name:     "utf16-key-order",
+		jsonText: `{"\u20ac": 1, "\r": 2, "\ufb33": 3, "1": 4, "\ud83d\ude00": 5, "\u0080": 6, "\u00f6": 7}`,
+		expected: "{\"\\r\":2,\"1\":4,\"\u0080\":6,\"\u00f6\":7,\"\u20ac\":1,\"\U0001f600\":5,\"\ufb33\":3}",
+	},
+	{
+		name:     "nested",
+		jsonText: `{"b": [{"d": 1, "c": 2}, []], "a": {}}`,
+		expected: `{"a":{},"b":[{"c":2,"d":1},[]]}`,
+	},
+	{
+		name:     "no-html-escaping",
+		jsonText: `"<a href='x'>&</a> \u2028"`,
+		expected: "\"<a href='x'>&</a> \u2028\"",
+	},
+	{
+		name:     "control-characters",
+		jsonText: `"\u0000\u0008\u0009\u000c\u000d\u001f\u007f"`,
+		expected: "\"\\u0000\\b\\t\\f\\r\\u001f\u007f\"",
+	},
+	{
+		name:     "null",
+		jsonText: `null`,
+		expected: `null`,
+	},
+}
+
+var testCasesForCanonicalizeNumbers = []struct {
+	number   string
+	expected string
+}{
+	{number: "0", expected: "0"},
+	{number: "-0", expected: "0"},
+	{number: "-0.0e5", expected: "0"},
+	{number: "1", expected: "1"},
+	{number: "1.0", expected: "1"},
+	{number: "-1.5", expected: "-1.5"},
+	{number: "100", expected: "100"},
+	{number: "1e2", expected: "100"},
+	{number: "123456789012345680000", expected: "123456789012345680000"},
+	{number: "1e20", expected: "100000000000000000000"},
+	{number: "1e21", expected: "1e+21"},
+	{number: "1.5e21", expected: "1.5e+21"},
+	{number: "0.000001", expected: "0.000001"},
+	{number: "0.0000001", expected: "1e-7"},
+	{number: "-1.2345e-7", expected: "-1.2345e-7"},
+	{number: "9007199254740991", expected: "9007199254740991"},
+	{number: "9007199254740993", expected: "9007199254740992"},
+	{number: "1.7976931348623157e308", expected: "1.7976931348623157e+308"},
+	{number: "5e-324", expected: "5e-324"},
+	{number: "0.1", expected: "0.1"},
+	{number: "0.30000000000000004", expected: "0.30000000000000004"},
+	{number: "295147905179352830000", expected: "295147905179352830000"},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestCanonicalHash(test *testing.T) {
+	test.Parallel()
+
+	hash1, err := jsonutil.CanonicalHash(`{"NAME": "Joe", "AGE": 35.0, "CITY": "Las Vegas"}`)
+	require.NoError(test, err)
+	assert.Len(test, hash1, 64)
+
+	hash2, err := jsonutil.CanonicalHash("{\"CITY\":\"Las\\u0020Vegas\",\n\"AGE\":3.5e1,\"NAME\":\"Joe\"}")
+	require.NoError(test, err)
+	assert.Equal(test, hash1, hash2)
+
+	hash3, err := jsonutil.CanonicalHash(`{"NAME": "Joe", "AGE": 36, "CITY": "Las Vegas"}`)
+	require.NoError(test, err)
+	assert.NotEqual(test, hash1, hash3)
+
+	hash4, err := jsonutil.CanonicalHash(`null`)
+	require.NoError(test, err)
+	assert.Equal(test, "74234e98afe7498fb5daf1f36ac2d78acc339464f950703b8c019892f982b90b", hash4)
+}
+
+// Large integer IDs are rounded to doubles, so only IDs kept as strings are told apart.
+func TestCanonicalHash_largeIDs(test *testing.T) {
+	test.Parallel()
+
+	hash1, err := jsonutil.CanonicalHash(`{"ENTITY_ID": 9007199254740992}`)
+	require.NoError(test, err)
+	hash2, err := jsonutil.CanonicalHash(`{"ENTITY_ID": 9007199254740993}`)
+	require.NoError(test, err)
+	assert.Equal(test, hash1, hash2)
+
+	hash1, err = jsonutil.CanonicalHash(`{"ENTITY_ID": "9007199254740992"}`)
+	require.NoError(test, err)
+	hash2, err = jsonutil.CanonicalHash(`{"ENTITY_ID": "9007199254740993"}`)
+	require.NoError(test, err)
+	assert.NotEqual(test, hash1, hash2)
+}
+
+func TestCanonicalHash_badJSON(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.CanonicalHash(badJSON)
+	require.Error(test, err)
+}
+
+func TestCanonicalize(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForCanonicalize {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.Canonicalize(testCase.jsonText)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestCanonicalize_badJSON(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.Canonicalize(badJSON)
+	require.Error(test, err)
+	assert.Equal(test, badJSON, actual)
+}
+
+func TestCanonicalize_hugeNumber(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"a": 1e400}`
+
+	actual, err := jsonutil.Canonicalize(jsonText)
+	require.Error(test, err)
+	assert.Equal(test, jsonText, actual)
+}
+
+func TestCanonicalize_numbers(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForCanonicalizeNumbers {
+		test.Run(testCase.number, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.Canonicalize(testCase.number)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
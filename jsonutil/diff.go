@@ -0,0 +1,375 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// Largest exponent of a number compared exactly. Larger exponents are compared as text.
+const maxExactExponent = 1000
+
+// JSON keys that can follow "." in a selector. Other keys use the "['key']" form.
+var simpleKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The String method returns a single-line, human-readable description of the difference.
+For example:
+
+	changed $.AGE: 35 -> 36
+
+Output
+  - The description.
+*/
+func (difference Difference) String() string {
+	switch difference.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("%s %s: %s", difference.Kind, difference.Path, renderValue(difference.New))
+	case DiffRemoved:
+		return fmt.Sprintf("%s %s: %s", difference.Kind, difference.Path, renderValue(difference.Old))
+	case DiffTypeChanged:
+		return fmt.Sprintf("%s %s: %s (%s) -> %s (%s)", difference.Kind, difference.Path,
+			renderValue(difference.Old), jsonType(difference.Old),
+			renderValue(difference.New), jsonType(difference.New))
+	default:
+		return fmt.Sprintf("%s %s: %s -> %s", difference.Kind, difference.Path,
+			renderValue(difference.Old), renderValue(difference.New))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Diff function compares two JSON documents structurally and returns their differences.
+Object keys are compared regardless of order.
+Numbers are compared by value, so 1, 1.0, and 1e0 are equal.
+
+Differences are listed in document order, with object keys in sorted order.
+An array element that is only in one document is reported as added or removed at its index in that document.
+With IgnoreArrayOrder, the removed elements of an array are listed before the added elements.
+
+Input
+  - jsonText1: The first ("old") JSON document.
+  - jsonText2: The second ("new") JSON document.
+  - options: Keys to ignore and whether array order matters.
+
+Output
+  - The differences. Empty if the documents are equivalent.
+  - An error if either text is not JSON.
+*/
+func Diff(jsonText1 string, jsonText2 string, options DiffOptions) ([]Difference, error) {
+	result := []Difference{}
+
+	value1, err := parseForDiff(jsonText1, options)
+	if err != nil {
+		return result, wraperror.Errorf(err, "Unmarshal jsonText1")
+	}
+
+	value2, err := parseForDiff(jsonText2, options)
+	if err != nil {
+		return result, wraperror.Errorf(err, "Unmarshal jsonText2")
+	}
+
+	result = diffValues("$", value1, value2, options, result)
+
+	return result, nil
+}
+
+/*
+The RenderDiff function returns a multi-line, human-readable description of differences, one per line.
+See [Difference.String].
+
+Input
+  - differences: Differences returned by [Diff].
+
+Output
+  - The description. Empty if there are no differences.
+*/
+func RenderDiff(differences []Difference) string {
+	var result strings.Builder
+
+	for _, difference := range differences {
+		result.WriteString(difference.String())
+		result.WriteString("\n")
+	}
+
+	return result.String()
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func appendIndex(path string, index int) string {
+	return path + "[" + strconv.Itoa(index) + "]"
+}
+
+func appendKey(path string, key string) string {
+	if simpleKeyPattern.MatchString(key) {
+		return path + "." + key
+	}
+
+	escaped := strings.ReplaceAll(key, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, `'`, `\'`)
+
+	return path + "['" + escaped + "']"
+}
+
+func diffArrays(path string, array1 []any, array2 []any, options DiffOptions, result []Difference) []Difference {
+	if options.IgnoreArrayOrder {
+		return diffUnorderedArrays(path, array1, array2, result)
+	}
+
+	for index := range max(len(array1), len(array2)) {
+		switch {
+		case index >= len(array1):
+			result = append(result, Difference{
+				Kind: DiffAdded,
+				New:  array2[index],
+				Old:  nil,
+				Path: appendIndex(path, index),
+			})
+		case index >= len(array2):
+			result = append(result, Difference{
+				Kind: DiffRemoved,
+				New:  nil,
+				Old:  array1[index],
+				Path: appendIndex(path, index),
+			})
+		default:
+			result = diffValues(appendIndex(path, index), array1[index], array2[index], options, result)
+		}
+	}
+
+	return result
+}
+
+func diffObjects(
+	path string,
+	object1 map[string]any,
+	object2 map[string]any,
+	options DiffOptions,
+	result []Difference,
+) []Difference {
+	keys := make([]string, 0, len(object1)+len(object2))
+
+	for key := range object1 {
+		keys = append(keys, key)
+	}
+
+	for key := range object2 {
+		if _, isInObject1 := object1[key]; !isInObject1 {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		value1, isInObject1 := object1[key]
+		value2, isInObject2 := object2[key]
+
+		switch {
+		case !isInObject1:
+			result = append(result, Difference{Kind: DiffAdded, New: value2, Old: nil, Path: appendKey(path, key)})
+		case !isInObject2:
+			result = append(result, Difference{Kind: DiffRemoved, New: nil, Old: value1, Path: appendKey(path, key)})
+		default:
+			result = diffValues(appendKey(path, key), value1, value2, options, result)
+		}
+	}
+
+	return result
+}
+
+/*
+Match equal elements regardless of position.
+Elements of the first array without an equal in the second are removed; the reverse are added.
+*/
+func diffUnorderedArrays(path string, array1 []any, array2 []any, result []Difference) []Difference {
+	unmatched := map[string][]int{}
+
+	for index, value := range array2 {
+		key := canonicalForDiff(value)
+		unmatched[key] = append(unmatched[key], index)
+	}
+
+	matched := make([]bool, len(array2))
+
+	for index, value := range array1 {
+		key := canonicalForDiff(value)
+
+		indexes := unmatched[key]
+		if len(indexes) == 0 {
+			result = append(result, Difference{Kind: DiffRemoved, New: nil, Old: value, Path: appendIndex(path, index)})
+
+			continue
+		}
+
+		matched[indexes[0]] = true
+		unmatched[key] = indexes[1:]
+	}
+
+	for index, value := range array2 {
+		if !matched[index] {
+			result = append(result, Difference{Kind: DiffAdded, New: value, Old: nil, Path: appendIndex(path, index)})
+		}
+	}
+
+	return result
+}
+
+func diffValues(path string, value1 any, value2 any, options DiffOptions, result []Difference) []Difference {
+	if jsonType(value1) != jsonType(value2) {
+		return append(result, Difference{Kind: DiffTypeChanged, New: value2, Old: value1, Path: path})
+	}
+
+	switch typedValue1 := value1.(type) {
+	case map[string]any:
+		typedValue2, _ := value2.(map[string]any)
+
+		return diffObjects(path, typedValue1, typedValue2, options, result)
+	case []any:
+		typedValue2, _ := value2.([]any)
+
+		return diffArrays(path, typedValue1, typedValue2, options, result)
+	case json.Number:
+		typedValue2, _ := value2.(json.Number)
+		if numbersEqual(typedValue1, typedValue2) {
+			return result
+		}
+	default:
+		if value1 == value2 {
+			return result
+		}
+	}
+
+	return append(result, Difference{Kind: DiffChanged, New: value2, Old: value1, Path: path})
+}
+
+/*
+Return a string that is equal for equal values: object keys are sorted by json.Marshal,
+arrays are sorted, and numbers are written in a canonical form.
+*/
+func canonicalForDiff(jsonValue any) string {
+	result, err := json.Marshal(canonicalValue(jsonValue))
+	if err != nil {
+		return fmt.Sprint(jsonValue)
+	}
+
+	return string(result)
+}
+
+func canonicalValue(jsonValue any) any {
+	switch typedJSON := jsonValue.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(typedJSON))
+		for key, value := range typedJSON {
+			result[key] = canonicalValue(value)
+		}
+
+		return result
+	case []any:
+		result := make([]string, 0, len(typedJSON))
+		for _, value := range typedJSON {
+			result = append(result, canonicalForDiff(value))
+		}
+
+		slices.Sort(result)
+
+		return result
+	case json.Number:
+		number, isNumber := parseRational(typedJSON)
+		if isNumber {
+			return json.Number(number.RatString())
+		}
+	}
+
+	return jsonValue
+}
+
+func jsonType(jsonValue any) string {
+	switch jsonValue.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number, float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", jsonValue)
+	}
+}
+
+func numbersEqual(number1 json.Number, number2 json.Number) bool {
+	if number1 == number2 {
+		return true
+	}
+
+	rational1, isRational1 := parseRational(number1)
+	rational2, isRational2 := parseRational(number2)
+
+	return isRational1 && isRational2 && rational1.Cmp(rational2) == 0
+}
+
+/*
+Parse a JSON number exactly.
+Numbers with huge exponents (e.g. 1e999999999) are not parsed, as their exact value would need huge amounts of memory.
+*/
+func parseRational(number json.Number) (*big.Rat, bool) {
+	text := number.String()
+
+	if index := strings.IndexAny(text, "eE"); index >= 0 {
+		exponent, err := strconv.Atoi(text[index+1:])
+		if err != nil || exponent > maxExactExponent || exponent < -maxExactExponent {
+			return nil, false
+		}
+	}
+
+	return new(big.Rat).SetString(text)
+}
+
+func parseForDiff(jsonText string, options DiffOptions) (any, error) {
+	result, err := unmarshalValue(jsonText)
+	if err != nil {
+		return result, err
+	}
+
+	if len(options.IgnoreKeys) > 0 {
+		stripMap := map[string]any{}
+		for _, ignoreKey := range options.IgnoreKeys {
+			stripMap[ignoreKey] = nil
+		}
+
+		stripFieldsFromValue(result, stripMap)
+	}
+
+	return result, nil
+}
+
+func renderValue(jsonValue any) string {
+	result, err := json.Marshal(jsonValue)
+	if err != nil {
+		return fmt.Sprint(jsonValue)
+	}
+
+	return string(result)
+}
@@ -0,0 +1,155 @@
+package jsonutil_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCasesForDiff = []struct {
+	name      string
+	jsonText1 string
+	jsonText2 string
+	options   jsonutil.DiffOptions
+	expected  string
+}{
+	{
+		name:      "equal",
+		jsonText1: `{"a": 1, "b": [1, 2, {"c": null}]}`,
+		jsonText2: `{"b": [1, 2, {"c": null}], "a": 1}`,
+		expected:  "",
+	},
+	{
+		name:      "equal-numbers",
+		jsonText1: `[1, 1.50, 100, 9007199254740993]`,
+		jsonText2: `[1.0, 1.5, 1e2, 9007199254740993]`,
+		expected:  "",
+	},
+	{
+		name:      "large-numbers",
+		jsonText1: `{"ENTITY_ID": 9007199254740993}`,
+		jsonText2: `{"ENTITY_ID": 9007199254740992}`,
+		expected:  "changed $.ENTITY_ID: 9007199254740993 -> 9007199254740992\n",
+	},
+	{
+		name:      "added-removed-changed",
+		jsonText1: `{"NAME": "Joe", "AGE": 35, "SSN": "111-22-3333"}`,
+		jsonText2: `{"NAME": "Joe", "AGE": 36, "EMAIL": "joe@example.com"}`,
+		expected: `changed $.AGE: 35 -> 36
+added $.EMAIL: "joe@example.com"
+removed $.SSN: "111-22-3333"
+`,
+	},
+	{
+		name:      "type-changed",
+		jsonText1: `{"ID": "1001", "X": null, "Y": [1]}`,
+		jsonText2: `{"ID": 1001, "X": false, "Y": {"0": 1}}`,
+		expected: `type-changed $.ID: "1001" (string) -> 1001 (number)
+type-changed $.X: null (null) -> false (boolean)
+type-changed $.Y: [1] (array) -> {"0":1} (object)
+`,
+	},
+	{
+		name:      "nested-and-arrays",
+		jsonText1: `{"RECORDS": [{"ID": 1, "NAME": "Joe"}, {"ID": 2}], "odd key": 1}`,
+		jsonText2: `{"RECORDS": [{"ID": 1, "NAME": "Joseph"}, {"ID": 2}, {"ID": 3}], "odd key": 2}`,
+		expected: `changed $.RECORDS[0].NAME: "Joe" -> "Joseph"
+added $.RECORDS[2]: {"ID":3}
+changed $['odd key']: 1 -> 2
+`,
+	},
+	{
+		name:      "array-order",
+		jsonText1: `[1, 2, 3]`,
+		jsonText2: `[3, 2]`,
+		expected: `changed $[0]: 1 -> 3
+removed $[2]: 3
+`,
+	},
+	{
+		name:      "ignore-array-order",
+		jsonText1: `{"A": [1, 2, 3, {"B": [4, 5]}], "C": [1, 1]}`,
+		jsonText2: `{"A": [{"B": [5, 4]}, 3, 2, 1], "C": [1, 2]}`,
+		options:   jsonutil.DiffOptions{IgnoreArrayOrder: true, IgnoreKeys: nil},
+		expected: `removed $.C[1]: 1
+added $.C[1]: 2
+`,
+	},
+	{
+		name:      "ignore-keys",
+		jsonText1: `{"LAST_SEEN_DT": "2024-01-01", "RECORDS": [{"LAST_SEEN_DT": "2024-01-01", "ID": 1}]}`,
+		jsonText2: `{"LAST_SEEN_DT": "2025-01-01", "RECORDS": [{"LAST_SEEN_DT": "2025-01-01", "ID": 1}]}`,
+		options:   jsonutil.DiffOptions{IgnoreArrayOrder: false, IgnoreKeys: []string{"LAST_SEEN_DT"}},
+		expected:  "",
+	},
+	{
+		name:      "null-documents",
+		jsonText1: `null`,
+		jsonText2: `{"a": 1}`,
+		expected:  "type-changed $: null (null) -> {\"a\":1} (object)\n",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestDiff(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForDiff {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.Diff(testCase.jsonText1, testCase.jsonText2, testCase.options)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, jsonutil.RenderDiff(actual))
+		})
+	}
+}
+
+func TestDiff_badJSON(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.Diff(badJSON, `{}`, jsonutil.DiffOptions{IgnoreArrayOrder: false, IgnoreKeys: nil})
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), "jsonText1")
+
+	_, err = jsonutil.Diff(`{}`, badJSON, jsonutil.DiffOptions{IgnoreArrayOrder: false, IgnoreKeys: nil})
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), "jsonText2")
+}
+
+func TestDiff_typedDifferences(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.Diff(`{"a": 1, "b": "x"}`, `{"a": 2, "c": true}`,
+		jsonutil.DiffOptions{IgnoreArrayOrder: false, IgnoreKeys: nil})
+	require.NoError(test, err)
+
+	expected := []jsonutil.Difference{
+		{Kind: jsonutil.DiffChanged, New: json.Number("2"), Old: json.Number("1"), Path: "$.a"},
+		{Kind: jsonutil.DiffRemoved, New: nil, Old: "x", Path: "$.b"},
+		{Kind: jsonutil.DiffAdded, New: true, Old: nil, Path: "$.c"},
+	}
+	assert.Equal(test, expected, actual)
+}
+
+func TestDiff_pathsAreSelectors(test *testing.T) {
+	test.Parallel()
+
+	jsonText1 := `{"a b": {"it's": [1, 2]}}`
+	jsonText2 := `{"a b": {"it's": [1, 3]}}`
+
+	differences, err := jsonutil.Diff(jsonText1, jsonText2, jsonutil.DiffOptions{IgnoreArrayOrder: false, IgnoreKeys: nil})
+	require.NoError(test, err)
+	require.Len(test, differences, 1)
+	assert.Equal(test, `$['a b']['it\'s'][1]`, differences[0].Path)
+
+	actual, err := jsonutil.RedactPaths(jsonText1, differences[0].Path)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"a b": {"it's": [1, null]}}`, actual)
+}
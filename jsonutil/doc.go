@@ -1,4 +1,37 @@
 /*
 Package jsonutil contains functions for manipulating JSON.
+
+Functions like [Normalize], [Redact], and [Strip] work on a single JSON value held in a string.
+[Redact] and [Strip] match keys by name anywhere in a document.
+To redact or remove exactly the values at particular paths, use [RedactPaths] and [StripPaths],
+which take JSONPath-like selectors (see [CompileSelector]).
+To redact sensitive values by their form (e.g. SSNs, email addresses, phone numbers) under any key
+or inside free text, use [RedactPII].
+To redact values while keeping records joinable, replace them with keyed pseudonyms (see [Pseudonymize]).
+To compare two documents structurally, ignoring key order and number formatting, use [Diff].
+To change documents, apply a JSON Patch (RFC 6902) with [ApplyPatch] or a JSON Merge Patch (RFC 7396)
+with [ApplyMergePatch]; [CreatePatch] and [CreateMergePatch] make patches from two documents.
+
+[NormalizeAndSort] is convenient for comparing results in Go, but its output is specific to this package.
+For a standard canonical form, use [Canonicalize] (RFC 8785), and to detect duplicate or changed documents,
+compare their [CanonicalHash].
+To read values from JSON (e.g. the RECORD_IDs of an entity) with selectors, use [ParseDocument].
+To flatten nested JSON into one object with keys like "RECORDS.0.DATA_SOURCE" (e.g. for CSV),
+use [FlattenObject], and to nest it again, use [Unflatten].
+To convert keys between naming conventions (e.g. Senzing's "ENTITY_ID" and "entityId"), use [ConvertKeyCase],
+or, to convert them back exactly, [KeyCaseMapping] and [ReverseKeyMapping] with [RenameKeys].
+When text is not JSON, errors locate the problem with a [SyntaxError].
+To accept JSON edited by hand, with comments, trailing commas, and single quotes, use [NormalizeLenient].
+To find duplicate keys, invalid UTF-8, and other problems that [IsJSON] accepts, use [ValidateStrict].
+To maintain settings or records in YAML or TOML, convert them with [YAMLToJSON] and [TOMLToJSON],
+and back with [JSONToYAML] and [JSONToTOML]; members keep their order where the format allows.
+To convert JSON to and from Go types (e.g. structs for Senzing records), use [ParseAs] and [MarshalNormalized];
+[RoundTrip] shows what a type keeps of a document.
+To format JSON for people to read, use [Format].
+To shorten JSON (e.g. for logs) while keeping it valid JSON, use [TruncateWithOptions].
+
+For large JSON lines (JSONL) files, functions like [NormalizeLines], [RedactLines], and [StripLines]
+read from an [io.Reader] and write to an [io.Writer] one line at a time, using bounded memory.
+Lines may be transformed in parallel; see [TransformLines].
 */
 package jsonutil
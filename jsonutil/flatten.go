@@ -0,0 +1,409 @@
+package jsonutil
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// The default FlattenOptions.Separator.
+const defaultFlattenSeparator = "."
+
+// A key segment between separators, with FlattenArraysBracketed: a name followed by any number of "[index]".
+var bracketedSegmentPattern = regexp.MustCompile(`^(.*?)((?:\[\d+\])*)$`)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+type flattener struct {
+	builder   strings.Builder
+	options   FlattenOptions
+	separator string
+	written   map[string]bool // Flattened keys already written.
+}
+
+// A part of a flattened key: an object member name, or (with FlattenArraysBracketed) an array index.
+type flattenToken struct {
+	isIndex bool
+	text    string
+}
+
+// A value being rebuilt by Unflatten: either a leaf value, or a container with children.
+type unflattenNode struct {
+	children []*unflattenNode
+	position map[flattenToken]int // Index in children and tokens, by token.
+	tokens   []flattenToken
+	value    *formatNode
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The FlattenObject function turns a JSON object into a flat JSON object whose member values are
+never objects, e.g. for exporting Senzing results to CSV.
+Each value is keyed by the keys on the way to it, joined by options.Separator:
+
+	{"RESOLVED_ENTITY": {"ENTITY_ID": 1, "RECORDS": [{"DATA_SOURCE": "CUSTOMERS"}]}}
+
+becomes
+
+	{"RESOLVED_ENTITY.ENTITY_ID": 1, "RESOLVED_ENTITY.RECORDS.0.DATA_SOURCE": "CUSTOMERS"}
+
+Array elements are keyed as options.Arrays says: by index, by bracketed index ("RECORDS[0].DATA_SOURCE"),
+or not at all, with arrays kept as values.
+Empty arrays and objects are kept as values, so that [Unflatten] can restore them.
+Members are kept in their original order.
+
+Not to be confused with [Flatten], which combines a JSON string and an error.
+
+Input
+  - jsonText: The JSON object to be flattened.
+  - options: How to make the flattened keys.
+
+Output
+  - The flattened JSON object.
+  - An error if the text is not a JSON object, or two values would have the same flattened key
+    (e.g. {"A.B": 1, "A": {"B": 2}}).
+*/
+func FlattenObject(jsonText string, options FlattenOptions) (string, error) {
+	node, err := parseOrderedObject(jsonText)
+	if err != nil {
+		return jsonText, wraperror.Error(err)
+	}
+
+	jsonFlattener := &flattener{
+		builder:   strings.Builder{},
+		options:   options,
+		separator: flattenSeparator(options),
+		written:   map[string]bool{},
+	}
+
+	jsonFlattener.builder.WriteByte('{')
+
+	for index, child := range node.children {
+		err = jsonFlattener.flatten(child, node.keys[index])
+		if err != nil {
+			return jsonText, err
+		}
+	}
+
+	jsonFlattener.builder.WriteByte('}')
+
+	return jsonFlattener.builder.String(), nil
+}
+
+/*
+The Unflatten function reverses [FlattenObject], rebuilding nested JSON from a flat JSON object.
+Keys are split at options.Separator, and members with the same leading keys are nested in the same object.
+
+With FlattenArraysBracketed, bracketed indexes (e.g. "RECORDS[0]") make arrays.
+With FlattenArraysIndexed, an object whose keys are exactly the indexes 0, 1, 2, ... becomes an array.
+With FlattenArraysKeep, no arrays are made, but arrays that are values are kept.
+Members and elements are in the order their keys first appear.
+
+The result is the original object if no original key contained options.Separator
+(or, with FlattenArraysBracketed, ended with a bracketed number)
+and, with FlattenArraysIndexed, no original object had only index-like keys.
+
+Input
+  - jsonText: The flat JSON object.
+  - options: How the flattened keys were made.
+
+Output
+  - The nested JSON object.
+  - An error if the text is not a JSON object, or the keys are inconsistent: a key is repeated,
+    a value is also a container (e.g. {"A": 1, "A.B": 2}), or, with FlattenArraysBracketed,
+    a container has both indexes and names, or an array is missing elements.
+*/
+func Unflatten(jsonText string, options FlattenOptions) (string, error) {
+	node, err := parseOrderedObject(jsonText)
+	if err != nil {
+		return jsonText, wraperror.Error(err)
+	}
+
+	root := newUnflattenNode()
+
+	for index, child := range node.children {
+		tokens := splitFlattenedKey(node.keys[index], options)
+
+		err = root.insert(tokens, &child)
+		if err != nil {
+			return jsonText, wraperror.Errorf(err, "key %s", node.keys[index])
+		}
+	}
+
+	result, err := root.rebuild(options, true)
+	if err != nil {
+		return jsonText, err
+	}
+
+	var builder strings.Builder
+
+	writeCompact(&builder, result)
+
+	return builder.String(), nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Write the flattened members for a value under the given flattened key.
+func (jsonFlattener *flattener) flatten(node formatNode, key string) error {
+	isLeaf := node.kind == 0 || len(node.children) == 0 ||
+		(node.kind == '[' && jsonFlattener.options.Arrays == FlattenArraysKeep)
+
+	if !isLeaf {
+		for index, child := range node.children {
+			err := jsonFlattener.flatten(child, jsonFlattener.childKey(node, index, key))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if jsonFlattener.written[key] {
+		return wraperror.Errorf(errForPackage, "%s is the flattened key of more than one value", key)
+	}
+
+	jsonFlattener.written[key] = true
+
+	if len(jsonFlattener.written) > 1 {
+		jsonFlattener.builder.WriteByte(',')
+	}
+
+	jsonFlattener.builder.WriteString(encodeString(key))
+	jsonFlattener.builder.WriteByte(':')
+	writeCompact(&jsonFlattener.builder, node)
+
+	return nil
+}
+
+// The flattened key of a child of an array or object.
+func (jsonFlattener *flattener) childKey(node formatNode, index int, key string) string {
+	switch {
+	case node.kind == '{':
+		return key + jsonFlattener.separator + node.keys[index]
+	case jsonFlattener.options.Arrays == FlattenArraysBracketed:
+		return key + "[" + strconv.Itoa(index) + "]"
+	default:
+		return key + jsonFlattener.separator + strconv.Itoa(index)
+	}
+}
+
+// Add the value at the path of tokens below the node.
+func (node *unflattenNode) insert(tokens []flattenToken, value *formatNode) error {
+	switch {
+	case node.value != nil && len(tokens) == 0:
+		return wraperror.Errorf(errForPackage, "the key is repeated")
+	case node.value != nil:
+		return wraperror.Errorf(errForPackage, "a value is also a container")
+	case len(tokens) == 0 && len(node.children) > 0:
+		return wraperror.Errorf(errForPackage, "a container is also a value")
+	case len(tokens) == 0:
+		node.value = value
+
+		return nil
+	}
+
+	position, isKnown := node.position[tokens[0]]
+	if !isKnown {
+		position = len(node.children)
+		node.position[tokens[0]] = position
+		node.tokens = append(node.tokens, tokens[0])
+		node.children = append(node.children, newUnflattenNode())
+	}
+
+	return node.children[position].insert(tokens[1:], value)
+}
+
+// Make the formatNode for the rebuilt value. The root is always an object.
+func (node *unflattenNode) rebuild(options FlattenOptions, isRoot bool) (formatNode, error) {
+	if node.value != nil {
+		return *node.value, nil
+	}
+
+	result := formatNode{
+		children: make([]formatNode, 0, len(node.children)),
+		keys:     nil,
+		kind:     '{',
+		scalar:   "",
+	}
+
+	order, isArray, err := node.arrayOrder(options, isRoot)
+	if err != nil {
+		return result, err
+	}
+
+	if isArray {
+		result.kind = '['
+	}
+
+	for _, position := range order {
+		child, err := node.children[position].rebuild(options, false)
+		if err != nil {
+			return result, err
+		}
+
+		result.children = append(result.children, child)
+
+		if !isArray {
+			result.keys = append(result.keys, node.tokens[position].text)
+		}
+	}
+
+	return result, nil
+}
+
+/*
+Decide whether the node is an array. Returns the positions of its children in output order.
+With FlattenArraysBracketed, an array's tokens must be the indexes 0 to n-1; with FlattenArraysIndexed,
+an object's must not be.
+*/
+func (node *unflattenNode) arrayOrder(options FlattenOptions, isRoot bool) ([]int, bool, error) {
+	inOrder := make([]int, len(node.children))
+	for position := range inOrder {
+		inOrder[position] = position
+	}
+
+	if isRoot || options.Arrays == FlattenArraysKeep {
+		return inOrder, false, nil
+	}
+
+	byIndex := make([]int, len(node.children))
+	indexCount := 0
+
+	for position, token := range node.tokens {
+		index, err := strconv.Atoi(token.text)
+
+		isIndex := token.isIndex ||
+			(options.Arrays == FlattenArraysIndexed && err == nil && token.text == strconv.Itoa(index))
+		if !isIndex {
+			continue
+		}
+
+		if err != nil || index < 0 || index >= len(byIndex) {
+			if token.isIndex {
+				return nil, false, wraperror.Errorf(errForPackage, "an array is missing elements before [%s]",
+					token.text)
+			}
+
+			continue
+		}
+
+		byIndex[index] = position
+		indexCount++
+	}
+
+	if options.Arrays == FlattenArraysBracketed && indexCount > 0 && indexCount < len(node.tokens) {
+		return nil, false, wraperror.Errorf(errForPackage, "an object has indexes, or an array has names")
+	}
+
+	if indexCount < len(node.tokens) {
+		return inOrder, false, nil
+	}
+
+	return byIndex, true, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func flattenSeparator(options FlattenOptions) string {
+	if options.Separator == "" {
+		return defaultFlattenSeparator
+	}
+
+	return options.Separator
+}
+
+func newUnflattenNode() *unflattenNode {
+	return &unflattenNode{
+		children: nil,
+		position: map[flattenToken]int{},
+		tokens:   nil,
+		value:    nil,
+	}
+}
+
+// Parse a JSON object, keeping the order of its members.
+func parseOrderedObject(jsonText string) (formatNode, error) {
+	result := formatNode{
+		children: nil,
+		keys:     nil,
+		kind:     0,
+		scalar:   "",
+	}
+
+	jsonValue, err := unmarshalValue(jsonText)
+	if err != nil {
+		return result, wraperror.Errorf(err, "Unmarshal")
+	}
+
+	if _, isObject := jsonValue.(map[string]any); !isObject {
+		return result, wraperror.Errorf(errForPackage, "want a JSON object, got %s", jsonType(jsonValue))
+	}
+
+	return parseOrdered(jsonText)
+}
+
+// Split a flattened key into the names and indexes it was made from.
+func splitFlattenedKey(key string, options FlattenOptions) []flattenToken {
+	segments := strings.Split(key, flattenSeparator(options))
+	result := make([]flattenToken, 0, len(segments))
+
+	for _, segment := range segments {
+		if options.Arrays != FlattenArraysBracketed {
+			result = append(result, flattenToken{isIndex: false, text: segment})
+
+			continue
+		}
+
+		match := bracketedSegmentPattern.FindStringSubmatch(segment)
+		result = append(result, flattenToken{isIndex: false, text: match[1]})
+
+		for index := range strings.SplitSeq(strings.TrimSuffix(strings.TrimPrefix(match[2], "["), "]"), "][") {
+			if index != "" {
+				// Leading zeros are ignored, so that "[01]" is the same element as "[1]".
+				result = append(result, flattenToken{isIndex: true, text: strings.TrimLeft(index[:len(index)-1], "0") +
+					index[len(index)-1:]})
+			}
+		}
+	}
+
+	return result
+}
+
+// Write the node as compact JSON, with no whitespace.
+func writeCompact(builder *strings.Builder, node formatNode) {
+	if node.kind == 0 {
+		builder.WriteString(node.scalar)
+
+		return
+	}
+
+	builder.WriteRune(rune(node.kind))
+
+	for index, child := range node.children {
+		if index > 0 {
+			builder.WriteByte(',')
+		}
+
+		if node.kind == '{' {
+			builder.WriteString(encodeString(node.keys[index]))
+			builder.WriteByte(':')
+		}
+
+		writeCompact(builder, child)
+	}
+
+	builder.WriteByte(closingDelimiter(node.kind))
+}
@@ -0,0 +1,243 @@
+package jsonutil_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonTextForFlatten = `{
+	"RESOLVED_ENTITY": {
+		"ENTITY_ID": 9007199254740993,
+		"RECORDS": [
+			{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"},
+			{"DATA_SOURCE": "WATCHLIST", "RECORD_ID": "1003", "FEATURES": [[1, 2], []]}
+		]
+	},
+	"EMPTY": {},
+	"NOTES": null
+}`
+
+var testCasesForFlattenObject = []struct {
+	name     string
+	options  jsonutil.FlattenOptions
+	expected string
+}{
+	{
+		name:     "indexed",
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""},
+		expected: `{"RESOLVED_ENTITY.ENTITY_ID":9007199254740993,"RESOLVED_ENTITY.RECORDS.0.DATA_SOURCE":"CUSTOMERS","RESOLVED_ENTITY.RECORDS.0.RECORD_ID":"1001","RESOLVED_ENTITY.RECORDS.1.DATA_SOURCE":"WATCHLIST","RESOLVED_ENTITY.RECORDS.1.RECORD_ID":"1003","RESOLVED_ENTITY.RECORDS.1.FEATURES.0.0":1,"RESOLVED_ENTITY.RECORDS.1.FEATURES.0.1":2,"RESOLVED_ENTITY.RECORDS.1.FEATURES.1":[],"EMPTY":{},"NOTES":null}`,
+	},
+	{
+		name:     "bracketed",
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysBracketed, Separator: ""},
+		expected: `{"RESOLVED_ENTITY.ENTITY_ID":9007199254740993,"RESOLVED_ENTITY.RECORDS[0].DATA_SOURCE":"CUSTOMERS","RESOLVED_ENTITY.RECORDS[0].RECORD_ID":"1001","RESOLVED_ENTITY.RECORDS[1].DATA_SOURCE":"WATCHLIST","RESOLVED_ENTITY.RECORDS[1].RECORD_ID":"1003","RESOLVED_ENTITY.RECORDS[1].FEATURES[0][0]":1,"RESOLVED_ENTITY.RECORDS[1].FEATURES[0][1]":2,"RESOLVED_ENTITY.RECORDS[1].FEATURES[1]":[],"EMPTY":{},"NOTES":null}`,
+	},
+	{
+		name:     "keep",
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysKeep, Separator: ""},
+		expected: `{"RESOLVED_ENTITY.ENTITY_ID":9007199254740993,"RESOLVED_ENTITY.RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"},{"DATA_SOURCE":"WATCHLIST","RECORD_ID":"1003","FEATURES":[[1,2],[]]}],"EMPTY":{},"NOTES":null}`,
+	},
+	{
+		name:     "separator",
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: "__"},
+		expected: `{"RESOLVED_ENTITY__ENTITY_ID":9007199254740993,"RESOLVED_ENTITY__RECORDS__0__DATA_SOURCE":"CUSTOMERS","RESOLVED_ENTITY__RECORDS__0__RECORD_ID":"1001","RESOLVED_ENTITY__RECORDS__1__DATA_SOURCE":"WATCHLIST","RESOLVED_ENTITY__RECORDS__1__RECORD_ID":"1003","RESOLVED_ENTITY__RECORDS__1__FEATURES__0__0":1,"RESOLVED_ENTITY__RECORDS__1__FEATURES__0__1":2,"RESOLVED_ENTITY__RECORDS__1__FEATURES__1":[],"EMPTY":{},"NOTES":null}`,
+	},
+}
+
+var testCasesForUnflatten = []struct {
+	name     string
+	jsonText string
+	options  jsonutil.FlattenOptions
+	expected string
+}{
+	{
+		name:     "indexed-array",
+		jsonText: `{"A.1": "b", "A.0": "a"}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""},
+		expected: `{"A":["a","b"]}`,
+	},
+	{
+		name:     "indexed-missing-element",
+		jsonText: `{"A.1": "b"}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""},
+		expected: `{"A":{"1":"b"}}`,
+	},
+	{
+		name:     "indexed-not-index",
+		jsonText: `{"A.0": "a", "A.01": "b"}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""},
+		expected: `{"A":{"0":"a","01":"b"}}`,
+	},
+	{
+		name:     "indexed-top-level",
+		jsonText: `{"0": "a"}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""},
+		expected: `{"0":"a"}`,
+	},
+	{
+		name:     "bracketed-numeric-name",
+		jsonText: `{"A.0": "a"}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysBracketed, Separator: ""},
+		expected: `{"A":{"0":"a"}}`,
+	},
+	{
+		name:     "bracketed-leading-zeros",
+		jsonText: `{"A[01]": "b", "A[0]": "a"}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysBracketed, Separator: ""},
+		expected: `{"A":["a","b"]}`,
+	},
+	{
+		name:     "bracketed-empty-key",
+		jsonText: `{"[0]": "a", "[1].B": "b"}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysBracketed, Separator: ""},
+		expected: `{"":["a",{"B":"b"}]}`,
+	},
+	{
+		name:     "keep",
+		jsonText: `{"A.0": "a", "B.C": [1]}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysKeep, Separator: ""},
+		expected: `{"A":{"0":"a"},"B":{"C":[1]}}`,
+	},
+	{
+		name:     "empty",
+		jsonText: `{}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""},
+		expected: `{}`,
+	},
+}
+
+var testCasesForUnflattenErrors = []struct {
+	name     string
+	jsonText string
+	options  jsonutil.FlattenOptions
+}{
+	{
+		name:     "bad-json",
+		jsonText: badJSON,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""},
+	},
+	{
+		name:     "not-object",
+		jsonText: `["A"]`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""},
+	},
+	{
+		name:     "repeated-key",
+		jsonText: `{"A.B": 1, "A.B": 2}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""},
+	},
+	{
+		name:     "value-then-container",
+		jsonText: `{"A": 1, "A.B": 2}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""},
+	},
+	{
+		name:     "container-then-value",
+		jsonText: `{"A.B": 1, "A": {}}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""},
+	},
+	{
+		name:     "bracketed-missing-element",
+		jsonText: `{"A[1]": 1}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysBracketed, Separator: ""},
+	},
+	{
+		name:     "bracketed-huge-index",
+		jsonText: `{"A[99999999999999999999]": 1}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysBracketed, Separator: ""},
+	},
+	{
+		name:     "bracketed-index-and-name",
+		jsonText: `{"A[0]": 1, "A.B": 2}`,
+		options:  jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysBracketed, Separator: ""},
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestFlattenObject(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForFlattenObject {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.FlattenObject(jsonTextForFlatten, testCase.options)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+
+			roundTrip, err := jsonutil.Unflatten(actual, testCase.options)
+			require.NoError(test, err)
+			assert.JSONEq(test, jsonTextForFlatten, roundTrip)
+		})
+	}
+}
+
+func TestFlattenObject_badJSON(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.FlattenObject(badJSON, jsonutil.FlattenOptions{
+		Arrays:    jsonutil.FlattenArraysIndexed,
+		Separator: "",
+	})
+	require.Error(test, err)
+	assert.Equal(test, badJSON, actual)
+}
+
+func TestFlattenObject_collision(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.FlattenObject(`{"A.B": 1, "A": {"B": 2}}`, jsonutil.FlattenOptions{
+		Arrays:    jsonutil.FlattenArraysIndexed,
+		Separator: "",
+	})
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), `A.B is the flattened key of more than one value`)
+	assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+}
+
+func TestFlattenObject_notObject(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.FlattenObject(`[{"A": 1}]`, jsonutil.FlattenOptions{
+		Arrays:    jsonutil.FlattenArraysIndexed,
+		Separator: "",
+	})
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), "want a JSON object, got array")
+}
+
+func TestUnflatten(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForUnflatten {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.Unflatten(testCase.jsonText, testCase.options)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestUnflatten_errors(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForUnflattenErrors {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.Unflatten(testCase.jsonText, testCase.options)
+			require.Error(test, err)
+			assert.Equal(test, testCase.jsonText, actual)
+			assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+		})
+	}
+}
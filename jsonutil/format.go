@@ -0,0 +1,336 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ANSI escape sequences for FormatOptions.Color, similar to those of jq.
+const (
+	colorBoolean = "\x1b[33m"   // Yellow.
+	colorKey     = "\x1b[34;1m" // Bold blue.
+	colorNull    = "\x1b[90m"   // Gray.
+	colorNumber  = "\x1b[36m"   // Cyan.
+	colorReset   = "\x1b[0m"
+	colorString  = "\x1b[32m" // Green.
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+A formatNode is a parsed JSON value that keeps the order of object members.
+Scalars are kept as JSON text.
+*/
+type formatNode struct {
+	children []formatNode // Array elements, or object member values.
+	keys     []string     // Object member keys.
+	kind     json.Delim   // '[' or '{'. Zero for scalars.
+	scalar   string
+}
+
+type formatter struct {
+	builder strings.Builder
+	options FormatOptions
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Format function writes JSON text in a readable, multi-line form.
+Unlike [PrettyPrint], it returns an error for text that is not JSON.
+
+Arrays and objects are written with one element or member per line, indented by options.Indent
+and prefixed by options.Prefix, as by [json.Indent].
+If options.MaxWidth is positive, an array that fits within that many columns is written on one line
+(e.g. "[1, 2, 3]").
+Object members keep their order unless options.SortKeys is true.
+If options.Color is true, keys and values are colored with ANSI escape sequences, for terminals;
+colors do not count towards options.MaxWidth.
+
+Numbers are preserved exactly as written; strings are written with standard JSON escapes.
+
+Input
+  - jsonText: The JSON text to be formatted.
+  - options: How to format the JSON.
+
+Output
+  - The formatted JSON text.
+  - An error if the text is not JSON.
+*/
+func Format(jsonText string, options FormatOptions) (string, error) {
+	node, err := parseOrdered(jsonText)
+	if err != nil {
+		return jsonText, err
+	}
+
+	if options.SortKeys {
+		node.sortKeys()
+	}
+
+	jsonFormatter := &formatter{
+		builder: strings.Builder{},
+		options: options,
+	}
+	jsonFormatter.write(node, 0, 0, 0)
+
+	return jsonFormatter.builder.String(), nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Write the node on one line, e.g. {"a": [1, 2]}.
+func (jsonFormatter *formatter) compact(node formatNode) {
+	switch node.kind {
+	case '[':
+		jsonFormatter.builder.WriteByte('[')
+
+		for index, child := range node.children {
+			if index > 0 {
+				jsonFormatter.builder.WriteString(", ")
+			}
+
+			jsonFormatter.compact(child)
+		}
+
+		jsonFormatter.builder.WriteByte(']')
+	case '{':
+		jsonFormatter.builder.WriteByte('{')
+
+		for index, child := range node.children {
+			if index > 0 {
+				jsonFormatter.builder.WriteString(", ")
+			}
+
+			jsonFormatter.key(node.keys[index])
+			jsonFormatter.compact(child)
+		}
+
+		jsonFormatter.builder.WriteByte('}')
+	default:
+		jsonFormatter.scalar(node.scalar)
+	}
+}
+
+func (jsonFormatter *formatter) key(key string) {
+	jsonFormatter.writeColored(colorKey, encodeString(key))
+	jsonFormatter.builder.WriteString(": ")
+}
+
+// Start a new line at the indentation of depth. Returns the width of the prefix and indentation.
+func (jsonFormatter *formatter) newline(depth int) int {
+	jsonFormatter.builder.WriteByte('\n')
+	jsonFormatter.builder.WriteString(jsonFormatter.options.Prefix)
+
+	for range depth {
+		jsonFormatter.builder.WriteString(jsonFormatter.options.Indent)
+	}
+
+	return utf8.RuneCountInString(jsonFormatter.options.Prefix) +
+		depth*utf8.RuneCountInString(jsonFormatter.options.Indent)
+}
+
+func (jsonFormatter *formatter) scalar(scalar string) {
+	switch scalar[0] {
+	case '"':
+		jsonFormatter.writeColored(colorString, scalar)
+	case 't', 'f':
+		jsonFormatter.writeColored(colorBoolean, scalar)
+	case 'n':
+		jsonFormatter.writeColored(colorNull, scalar)
+	default:
+		jsonFormatter.writeColored(colorNumber, scalar)
+	}
+}
+
+/*
+Write the node at the given depth.
+used is the width of the line before the node, and trailing the width of what follows it on the line (e.g. ",").
+*/
+func (jsonFormatter *formatter) write(node formatNode, depth int, used int, trailing int) {
+	if node.kind == 0 || len(node.children) == 0 {
+		jsonFormatter.compact(node)
+
+		return
+	}
+
+	maxWidth := jsonFormatter.options.MaxWidth
+	if node.kind == '[' && maxWidth > 0 && used+compactWidth(node)+trailing <= maxWidth {
+		jsonFormatter.compact(node)
+
+		return
+	}
+
+	jsonFormatter.builder.WriteRune(rune(node.kind))
+
+	for index, child := range node.children {
+		childUsed := jsonFormatter.newline(depth + 1)
+
+		if node.kind == '{' {
+			jsonFormatter.key(node.keys[index])
+			childUsed += utf8.RuneCountInString(encodeString(node.keys[index])) + len(": ")
+		}
+
+		if index < len(node.children)-1 {
+			jsonFormatter.write(child, depth+1, childUsed, len(","))
+			jsonFormatter.builder.WriteByte(',')
+		} else {
+			jsonFormatter.write(child, depth+1, childUsed, 0)
+		}
+	}
+
+	jsonFormatter.newline(depth)
+	jsonFormatter.builder.WriteByte(closingDelimiter(node.kind))
+}
+
+func (jsonFormatter *formatter) writeColored(color string, text string) {
+	if !jsonFormatter.options.Color {
+		jsonFormatter.builder.WriteString(text)
+
+		return
+	}
+
+	jsonFormatter.builder.WriteString(color)
+	jsonFormatter.builder.WriteString(text)
+	jsonFormatter.builder.WriteString(colorReset)
+}
+
+// Sort object members by key, at every depth.
+func (node *formatNode) sortKeys() {
+	for index := range node.children {
+		node.children[index].sortKeys()
+	}
+
+	if node.kind != '{' {
+		return
+	}
+
+	order := make([]int, len(node.keys))
+	for index := range order {
+		order[index] = index
+	}
+
+	slices.SortStableFunc(order, func(index1 int, index2 int) int {
+		return strings.Compare(node.keys[index1], node.keys[index2])
+	})
+
+	keys := make([]string, len(order))
+	children := make([]formatNode, len(order))
+
+	for index, originalIndex := range order {
+		keys[index] = node.keys[originalIndex]
+		children[index] = node.children[originalIndex]
+	}
+
+	node.keys = keys
+	node.children = children
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Width of the node written on one line, without colors.
+func compactWidth(node formatNode) int {
+	uncolored := &formatter{
+		builder: strings.Builder{},
+		options: FormatOptions{
+			Color:    false,
+			Indent:   "",
+			MaxWidth: 0,
+			Prefix:   "",
+			SortKeys: false,
+		},
+	}
+	uncolored.compact(node)
+
+	return utf8.RuneCountInString(uncolored.builder.String())
+}
+
+// Encode a string as JSON, without the HTML escaping of json.Marshal.
+func encodeString(text string) string {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(text) // Encoding a string cannot fail.
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// Parse JSON text, keeping the order of object members.
+func parseOrdered(jsonText string) (formatNode, error) {
+	_, err := unmarshal(jsonText)
+	if err != nil {
+		return formatNode{children: nil, keys: nil, kind: 0, scalar: ""}, wraperror.Errorf(err, "Unmarshal")
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(jsonText))
+	decoder.UseNumber()
+
+	result, err := parseFormatNode(decoder)
+
+	return result, wraperror.Errorf(err, "parseFormatNode")
+}
+
+// Parse the next JSON value from the decoder, keeping the order of object members.
+func parseFormatNode(decoder *json.Decoder) (formatNode, error) {
+	result := formatNode{
+		children: nil,
+		keys:     nil,
+		kind:     0,
+		scalar:   "",
+	}
+
+	token, err := decoder.Token()
+	if err != nil {
+		return result, wraperror.Errorf(err, "Token")
+	}
+
+	switch typedToken := token.(type) {
+	case json.Delim:
+		result.kind = typedToken
+
+		for decoder.More() {
+			if typedToken == '{' {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return result, wraperror.Errorf(err, "Token")
+				}
+
+				key, _ := keyToken.(string)
+				result.keys = append(result.keys, key)
+			}
+
+			child, err := parseFormatNode(decoder)
+			if err != nil {
+				return result, err
+			}
+
+			result.children = append(result.children, child)
+		}
+
+		_, err = decoder.Token() // The closing "]" or "}".
+	case string:
+		result.scalar = encodeString(typedToken)
+	case json.Number:
+		result.scalar = typedToken.String()
+	case bool:
+		result.scalar = strconv.FormatBool(typedToken)
+	default:
+		result.scalar = Null
+	}
+
+	return result, wraperror.Error(err)
+}
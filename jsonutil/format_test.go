@@ -0,0 +1,152 @@
+package jsonutil_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonTextForFormat = `{"NAME": "Joe <Schmoe>", "ENTITY_ID": 9007199254740993, "SCORES": [1, 2.50, 3], ` +
+	`"RECORDS": [{"RECORD_ID": "1001", "FEATURES": []}], "EMPTY": {}, "FLAGS": [true, false, null]}`
+
+var testCasesForFormat = []struct {
+	name     string
+	jsonText string
+	options  jsonutil.FormatOptions
+	expected string
+}{
+	{
+		name:     "indent",
+		jsonText: jsonTextForFormat,
+		options:  jsonutil.FormatOptions{Color: false, Indent: "  ", MaxWidth: 0, Prefix: "", SortKeys: false},
+		expected: `{
+  "NAME": "Joe <Schmoe>",
+  "ENTITY_ID": 9007199254740993,
+  "SCORES": [
+    1,
+    2.50,
+    3
+  ],
+  "RECORDS": [
+    {
+      "RECORD_ID": "1001",
+      "FEATURES": []
+    }
+  ],
+  "EMPTY": {},
+  "FLAGS": [
+    true,
+    false,
+    null
+  ]
+}`,
+	},
+	{
+		name:     "prefix-and-sorted-keys",
+		jsonText: `{"b": {"d": 1, "c": 2}, "a": [{"f": 3, "e": 4}]}`,
+		options:  jsonutil.FormatOptions{Color: false, Indent: "\t", MaxWidth: 0, Prefix: "> ", SortKeys: true},
+		expected: "{\n> \t\"a\": [\n> \t\t{\n> \t\t\t\"e\": 4,\n> \t\t\t\"f\": 3\n> \t\t}\n> \t],\n" +
+			"> \t\"b\": {\n> \t\t\"c\": 2,\n> \t\t\"d\": 1\n> \t}\n> }",
+	},
+	{
+		name:     "max-width",
+		jsonText: jsonTextForFormat,
+		options:  jsonutil.FormatOptions{Color: false, Indent: "  ", MaxWidth: 40, Prefix: "", SortKeys: false},
+		expected: `{
+  "NAME": "Joe <Schmoe>",
+  "ENTITY_ID": 9007199254740993,
+  "SCORES": [1, 2.50, 3],
+  "RECORDS": [
+    {
+      "RECORD_ID": "1001",
+      "FEATURES": []
+    }
+  ],
+  "EMPTY": {},
+  "FLAGS": [true, false, null]
+}`,
+	},
+	{
+		name:     "max-width-exact-fit",
+		jsonText: `[[1, 2], [3, 4]]`,
+		options:  jsonutil.FormatOptions{Color: false, Indent: "  ", MaxWidth: 9, Prefix: "", SortKeys: false},
+		expected: "[\n  [1, 2],\n  [3, 4]\n]",
+	},
+	{
+		name:     "max-width-too-narrow",
+		jsonText: `[[1, 2], [3, 4]]`,
+		options:  jsonutil.FormatOptions{Color: false, Indent: "  ", MaxWidth: 8, Prefix: "", SortKeys: false},
+		expected: "[\n  [\n    1,\n    2\n  ],\n  [3, 4]\n]",
+	},
+	{
+		name:     "max-width-whole-document",
+		jsonText: `[{"a": 1}, "x"]`,
+		options:  jsonutil.FormatOptions{Color: false, Indent: "  ", MaxWidth: 80, Prefix: "", SortKeys: false},
+		expected: `[{"a": 1}, "x"]`,
+	},
+	{
+		name:     "color",
+		jsonText: `{"a": ["s", 1, true, null]}`,
+		options:  jsonutil.FormatOptions{Color: true, Indent: " ", MaxWidth: 30, Prefix: "", SortKeys: false},
+		expected: "{\n \x1b[34;1m\"a\"\x1b[0m: [\x1b[32m\"s\"\x1b[0m, \x1b[36m1\x1b[0m, " +
+			"\x1b[33mtrue\x1b[0m, \x1b[90mnull\x1b[0m]\n}",
+	},
+	{
+		name:     "scalar",
+		jsonText: " \"\\u00e9\\t\" ",
+		options:  jsonutil.FormatOptions{Color: false, Indent: "  ", MaxWidth: 0, Prefix: "", SortKeys: false},
+		expected: "\"\u00e9\\t\"",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestFormat(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForFormat {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.Format(testCase.jsonText, testCase.options)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestFormat_badJSON(test *testing.T) {
+	test.Parallel()
+
+	for _, jsonText := range []string{badJSON, `{"a": 1`, `[1] [2]`, ``} {
+		actual, err := jsonutil.Format(jsonText, jsonutil.FormatOptions{
+			Color:    false,
+			Indent:   "  ",
+			MaxWidth: 0,
+			Prefix:   "",
+			SortKeys: false,
+		})
+		require.Error(test, err)
+		assert.Equal(test, jsonText, actual)
+	}
+}
+
+func TestFormat_matchesPrettyPrint(test *testing.T) {
+	test.Parallel()
+
+	expected := jsonutil.PrettyPrint(jsonTextForFormat, "\t")
+
+	actual, err := jsonutil.Format(jsonTextForFormat, jsonutil.FormatOptions{
+		Color:    false,
+		Indent:   "\t",
+		MaxWidth: 0,
+		Prefix:   "",
+		SortKeys: false,
+	})
+	require.NoError(test, err)
+	assert.Equal(test, expected, actual)
+}
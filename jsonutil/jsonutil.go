@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/senzing-garage/go-helpers/wraperror"
@@ -14,6 +13,9 @@ const (
 	Null = "null"
 )
 
+// Whitespace allowed around JSON values. See https://www.rfc-editor.org/rfc/rfc8259#section-2
+const jsonWhitespace = " \t\r\n"
+
 // ----------------------------------------------------------------------------
 // Public functions
 // ----------------------------------------------------------------------------
@@ -54,6 +56,7 @@ func IsJSON(unknownText string) bool {
 /*
 Normalizes the specified JSON text using the Go encoding/json marshaller to ensure it is formatted consistently.
 This should work with any JSON literal: objects, arrays, null, integers, booleans, decimal numbers, etc....
+Numbers are preserved exactly as written (e.g. 18446744073709551615 and 1.50 are not rewritten).
 
 Input
   - jsonText: The JSON text to be nornalized.
@@ -63,11 +66,9 @@ Output
   - An error if a failure occurred in interpretting/normalizing the specified text.
 */
 func Normalize(jsonText string) (string, error) {
-	var parsedJSON *any
-
 	// Unmarshall the text and let it allocate whatever object it wants to hold the result.
 
-	err := json.Unmarshal([]byte(jsonText), &parsedJSON)
+	parsedJSON, err := unmarshal(jsonText)
 	// Check for an unmarshalling error.
 	if err != nil {
 		return jsonText, wraperror.Errorf(err, "Unmarshal")
@@ -90,6 +91,8 @@ func Normalize(jsonText string) (string, error) {
 Normalizes the specified JSON text using the Go encoding/json marshaller to ensure it is formatted consistently,
 but also sorts any JSON arrays in a consistent manner.  This should work with any JSON literal: objects, arrays,
 null, integers, booleans, decimal numbers, etc....
+As with [Normalize], numbers are preserved exactly as written.
+To sort only some arrays, or to sort arrays of objects by key, use [NormalizeAndSortWithOptions].
 
 Input
   - jsonText: The JSON text to be normalized and sorted.
@@ -99,10 +102,8 @@ Output
   - An error if a failure occurred in interpretting/normalizing the specified text.
 */
 func NormalizeAndSort(jsonText string) (string, error) {
-	var parsedJSON *any
-
 	// unmarshall the text and let it allocate whatever object it wants to hold the result
-	err := json.Unmarshal([]byte(jsonText), &parsedJSON)
+	parsedJSON, err := unmarshal(jsonText)
 	// check for an unmarshalling error
 	if err != nil {
 		return jsonText, wraperror.Errorf(err, "Unmarshal")
@@ -114,7 +115,7 @@ func NormalizeAndSort(jsonText string) (string, error) {
 	}
 
 	// sort the parsed JSON value
-	sortValue(*parsedJSON)
+	sortValue(*parsedJSON, nil)
 
 	// marshall the parsed object back to text (bytes) and return the text and potential error
 	normalizedJSON, err := json.Marshal(*parsedJSON)
@@ -124,18 +125,19 @@ func NormalizeAndSort(jsonText string) (string, error) {
 
 /*
 PrettyPrint creates a multi-line, indented string representation of the submitted JSON.
+If the text is not JSON, it is returned unchanged. To detect that, or for more formatting options, use [Format].
 
 Input
   - jsonText: The JSON text to be "prettied".
   - padding: Indentation padding.
 
 Output
-  - PrettyPrinted JSON.
+  - PrettyPrinted JSON, or the submitted text if it is not JSON.
 */
 func PrettyPrint(jsonText string, padding string) string {
 	var prettyJSON bytes.Buffer
 	if err := json.Indent(&prettyJSON, []byte(jsonText), "", padding); err != nil {
-		panic(err)
+		return jsonText
 	}
 
 	return prettyJSON.String()
@@ -174,7 +176,8 @@ map and the JSON will be marshalled back into text and returned.  This should wo
 JSON literal: objects, arrays, null, integers, booleans, decimal numbers, etc.... However,
 this method has no effect on simple numeric, boolean, or null values.  NOTE: the redacted
 values should be values that can be marshalled back into JSON, if nil, then a JSON null will
-be used.
+be used.  If a redacted value is a [RedactionFunc] (e.g. [Pseudonymize]), it is called with the
+original value to compute the redacted value.
 
 Input
   - jsonText: The JSON text to be redacted.
@@ -186,10 +189,8 @@ Output
     redacted JSON.
 */
 func RedactWithMap(jsonText string, redactMap map[string]any) (string, error) {
-	var parsedJSON *any
-
 	// unmarshall the text and let it allocate whatever object it wants to hold the result
-	err := json.Unmarshal([]byte(jsonText), &parsedJSON)
+	parsedJSON, err := unmarshal(jsonText)
 	// check for an unmarshalling error
 	if err != nil {
 		return jsonText, wraperror.Errorf(err, "Unmarshal")
@@ -252,10 +253,8 @@ func Strip(jsonText string, removeKeys ...string) (string, error) {
 		stripMap[removeKey] = nil
 	}
 
-	var parsedJSON *any
-
 	// unmarshall the text and let it allocate whatever object it wants to hold the result
-	err := json.Unmarshal([]byte(jsonText), &parsedJSON)
+	parsedJSON, err := unmarshal(jsonText)
 	// check for an unmarshalling error
 	if err != nil {
 		return jsonText, wraperror.Errorf(err, "Unmarshal")
@@ -288,6 +287,7 @@ multi-line JSON representation into a single line of truncated JSON.
 If the JSON has been truncated,
 the string will be suffixed with an ellipsis ("...").
 Thus, the returned string may not be syntactically correct JSON.
+For truncated text that is always valid JSON, use [TruncateWithOptions].
 
 Input
   - jsonText: The JSON text to be truncated.
@@ -360,7 +360,7 @@ func redactObject(jsonObject map[string]any, redactMap map[string]any) {
 	for key, jsonValue := range jsonObject {
 		redactedValue, redacted := redactMap[key]
 		if redacted {
-			jsonObject[key] = redactedValue
+			jsonObject[key] = computeRedactedValue(redactedValue, jsonValue)
 		} else {
 			redactValue(jsonValue, redactMap)
 		}
@@ -376,49 +376,56 @@ func redactValue(jsonValue any, redactMap map[string]any) {
 	}
 }
 
-func sortArray(jsonArray []any) {
+func sortArray(jsonArray []any, byKeys []string) {
 	// sort each element in the array
 	for _, jsonValue := range jsonArray {
-		sortValue(jsonValue)
+		sortValue(jsonValue, byKeys)
 	}
 
 	// now sort the array itself
-	sort.Slice(jsonArray, func(iIndex, jIndex int) bool {
-		// special case JSON null values
-		if (jsonArray[iIndex] == nil) && (jsonArray[jIndex] == nil) {
-			return false
-		}
-
-		if (jsonArray[iIndex] == nil) && (jsonArray[jIndex] != nil) {
-			return true
-		}
-
-		if (jsonArray[iIndex] != nil) && (jsonArray[jIndex] == nil) {
-			return false
-		}
-
-		// otherwise marshal the value and compare the text
-		json1, _ := json.Marshal(jsonArray[iIndex])
-		json2, _ := json.Marshal(jsonArray[jIndex])
-
-		return (bytes.Compare(json1, json2) < 0)
-	})
+	sortArrayElements(jsonArray, byKeys)
 }
 
-func sortObject(jsonObject map[string]any) {
+func sortObject(jsonObject map[string]any, byKeys []string) {
 	// sort each value in the object
 	for _, jsonValue := range jsonObject {
-		sortValue(jsonValue)
+		sortValue(jsonValue, byKeys)
 	}
 }
 
-func sortValue(jsonValue any) {
+func sortValue(jsonValue any, byKeys []string) {
 	switch typedJSON := jsonValue.(type) {
 	case map[string]any:
-		sortObject(typedJSON)
+		sortObject(typedJSON, byKeys)
 	case []any:
-		sortArray(typedJSON)
+		sortArray(typedJSON, byKeys)
+	}
+}
+
+/*
+Unmarshal JSON text using json.Number for numbers, so numeric values are preserved byte-for-byte
+(e.g. 64-bit entity IDs that float64 cannot represent exactly).
+Errors are *SyntaxError, locating the error reported by json.Unmarshal.
+*/
+func unmarshal(jsonText string) (*any, error) {
+	var result *any
+
+	decoder := json.NewDecoder(strings.NewReader(jsonText))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&result)
+	if err == nil && len(strings.Trim(jsonText[decoder.InputOffset():], jsonWhitespace)) == 0 {
+		return result, nil
 	}
+
+	// Either the text is not JSON, or there is text after the JSON value.
+	// Either way, let json.Unmarshal describe the problem, and locate it.
+
+	var unused any
+
+	err = json.Unmarshal([]byte(jsonText), &unused)
+
+	return nil, newSyntaxError(jsonText, err)
 }
 
 func stripFieldsFromArray(jsonArray []any, stripMap map[string]any) {
@@ -1,7 +1,11 @@
 package jsonutil_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/senzing-garage/go-helpers/jsonutil"
 )
@@ -10,6 +14,106 @@ import (
 // Example functions
 // ----------------------------------------------------------------------------
 
+func ExampleApplyMergePatch() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/patch_test.go
+	jsonText := `{"NAME": "Joe", "SSN": "111-22-3333", "AGE": 35}`
+
+	patchedJSON, err := jsonutil.ApplyMergePatch(jsonText, `{"NAME": "Joseph", "SSN": null}`)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(patchedJSON)
+	// Output: {"AGE":35,"NAME":"Joseph"}
+}
+
+func ExampleApplyPatch() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/patch_test.go
+	jsonText := `{"NAME": "Joe", "RECORDS": ["CUSTOMERS-1001", "WATCHLIST-2001"]}`
+	patchText := `[{"op": "replace", "path": "/NAME", "value": "Joseph"}, {"op": "remove", "path": "/RECORDS/0"}]`
+
+	patchedJSON, err := jsonutil.ApplyPatch(jsonText, patchText)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(patchedJSON)
+	// Output: {"NAME":"Joseph","RECORDS":["WATCHLIST-2001"]}
+}
+
+func ExampleCanonicalize() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/canonical_test.go
+	jsonText := `{"NAME": "Jos\u00e9", "AGE": 35.0, "SCORE": 1E2}`
+
+	canonicalJSON, err := jsonutil.Canonicalize(jsonText)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(canonicalJSON)
+	// Output: {"AGE":35,"NAME":"José","SCORE":100}
+}
+
+func ExampleConvertKeyCase() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/keycase_test.go
+	jsonText := `{"RESOLVED_ENTITY": {"ENTITY_ID": 1, "RECORDS": [{"DATA_SOURCE": "CUSTOMERS"}]}}`
+
+	result, err := jsonutil.ConvertKeyCase(jsonText, jsonutil.KeyCaseCamel)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(result)
+	// Output: {"resolvedEntity":{"entityId":1,"records":[{"dataSource":"CUSTOMERS"}]}}
+}
+
+func ExampleCreatePatch() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/patch_test.go
+	jsonText1 := `{"NAME": "Joe", "AGE": 35, "SSN": "111-22-3333"}`
+	jsonText2 := `{"NAME": "Joe", "AGE": 36}`
+
+	patchText, err := jsonutil.CreatePatch(jsonText1, jsonText2)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(patchText)
+	// Output: [{"op":"remove","path":"/SSN"},{"op":"replace","path":"/AGE","value":36}]
+}
+
+func ExampleDiff() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/diff_test.go
+	jsonText1 := `{"NAME": "Joe", "AGE": 35, "SSN": "111-22-3333"}`
+	jsonText2 := `{"NAME": "Joe", "AGE": 36.0, "EMAIL": "joe@example.com"}`
+
+	differences, err := jsonutil.Diff(jsonText1, jsonText2, jsonutil.DiffOptions{IgnoreArrayOrder: false, IgnoreKeys: nil})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Print(jsonutil.RenderDiff(differences))
+	// Output:
+	// changed $.AGE: 35 -> 36.0
+	// added $.EMAIL: "joe@example.com"
+	// removed $.SSN: "111-22-3333"
+}
+
+func ExampleFlattenObject() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/flatten_test.go
+	jsonText := `{"ENTITY_ID": 1, "RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}]}`
+
+	result, err := jsonutil.FlattenObject(jsonText, jsonutil.FlattenOptions{
+		Arrays:    jsonutil.FlattenArraysIndexed,
+		Separator: ".",
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(result)
+	// Output: {"ENTITY_ID":1,"RECORDS.0.DATA_SOURCE":"CUSTOMERS","RECORDS.0.RECORD_ID":"1001"}
+}
+
 func ExampleFlatten_noError() {
 	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/jsonutil_test.go
 	jsonText := `{ "name": "Joe Schmoe", "ssn": "111-22-3333" }`
@@ -23,7 +127,31 @@ func ExampleFlatten_withError() {
 	jsonText := `{ "name": "Joe Schmoe" "ssn": "111-22-3333" }` // missing a comma
 	redactedJSON := jsonutil.Flatten(jsonutil.RedactWithMap(jsonText, map[string]any{"ssn": "***-**-****"}))
 	fmt.Println(redactedJSON)
-	// Output: {"function": "jsonutil.Flatten", "error": {"function": "jsonutil.RedactWithMap", "text": "Unmarshal", "error": "invalid character '\"' after object key:value pair"}}
+	// Output: {"function": "jsonutil.Flatten", "error": {"function": "jsonutil.RedactWithMap", "text": "Unmarshal", "error": "line 1, column 24 (offset 23): invalid character '\"' after object key:value pair"}}
+}
+
+func ExampleFormat() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/format_test.go
+	jsonText := `{"NAME": "Joe", "SCORES": [1, 2, 3], "RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}]}`
+	options := jsonutil.FormatOptions{Color: false, Indent: "  ", MaxWidth: 40, Prefix: "", SortKeys: false}
+
+	formattedJSON, err := jsonutil.Format(jsonText, options)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(formattedJSON)
+	// Output:
+	// {
+	//   "NAME": "Joe",
+	//   "SCORES": [1, 2, 3],
+	//   "RECORDS": [
+	//     {
+	//       "DATA_SOURCE": "CUSTOMERS",
+	//       "RECORD_ID": "1001"
+	//     }
+	//   ]
+	// }
 }
 
 func ExampleIsJSON() {
@@ -39,6 +167,91 @@ func ExampleIsJSON() {
 	// Output: {"givenName": "Joe","surname": "Schmoe","age": 35,"member": true} is valid JSON
 }
 
+func ExampleJSONToTOML() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/toml_test.go
+	jsonText := `{"PIPELINE": {"CONFIGPATH": "/etc/opt/senzing", "SUPPORTPATH": "/opt/senzing/data"}}`
+
+	tomlText, err := jsonutil.JSONToTOML(jsonText)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Print(tomlText)
+	// Output:
+	// [PIPELINE]
+	// CONFIGPATH = "/etc/opt/senzing"
+	// SUPPORTPATH = "/opt/senzing/data"
+}
+
+func ExampleJSONToYAML() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/yaml_test.go
+	jsonText := `{"PIPELINE": {"CONFIGPATH": "/etc/opt/senzing", "SUPPORTPATH": "/opt/senzing/data"}}`
+
+	yamlText, err := jsonutil.JSONToYAML(jsonText)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Print(yamlText)
+	// Output:
+	// PIPELINE:
+	//   CONFIGPATH: /etc/opt/senzing
+	//   SUPPORTPATH: /opt/senzing/data
+}
+
+func ExampleKeyCaseMapping() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/keycase_test.go
+	jsonText := `{"ENTITY_ID": 1, "entityName": "Robert Smith"}`
+
+	mapping, err := jsonutil.KeyCaseMapping(jsonText, jsonutil.KeyCaseLowerSnake)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	converted, err := jsonutil.RenameKeys(jsonText, mapping)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	reverseMapping, err := jsonutil.ReverseKeyMapping(mapping)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	restored, err := jsonutil.RenameKeys(converted, reverseMapping)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(converted)
+	fmt.Println(restored)
+	// Output:
+	// {"entity_id":1,"entity_name":"Robert Smith"}
+	// {"ENTITY_ID":1,"entityName":"Robert Smith"}
+}
+
+func ExampleMarshalNormalized() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/typed_test.go
+	type Record struct {
+		RecordID   string `json:"RECORD_ID"`
+		DataSource string `json:"DATA_SOURCE"`
+	}
+
+	jsonText, err := jsonutil.MarshalNormalized(Record{RecordID: "1001", DataSource: "CUSTOMERS"})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(jsonText)
+	// Output: {"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}
+}
+
+func ExampleMustMarshalNormalized() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/typed_test.go
+	fmt.Println(jsonutil.MustMarshalNormalized(map[string]any{"RECORD_ID": "1001", "DATA_SOURCE": "CUSTOMERS"}))
+	// Output: {"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}
+}
+
 func ExampleNormalize() {
 	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/jsonutil_test.go
 	jsonText := `
@@ -78,6 +291,94 @@ func ExampleNormalizeAndSort() {
 	// Output: {"age":29,"givenName":"Jane","member":true,"nicknames":["Joey","Joseph"],"surname":"Doe"}
 }
 
+func ExampleNormalizeAndSortWithOptions() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/sort_test.go
+	jsonText := `{"MATCH_KEYS": ["+NAME", "+DOB"], "RECORDS": [{"RECORD_ID": 10}, {"RECORD_ID": 9}]}`
+	options := jsonutil.SortOptions{ByKeys: []string{"RECORD_ID"}, Paths: []string{"$.RECORDS"}}
+
+	sortedJSON, err := jsonutil.NormalizeAndSortWithOptions(jsonText, options)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(sortedJSON)
+	// Output: {"MATCH_KEYS":["+NAME","+DOB"],"RECORDS":[{"RECORD_ID":9},{"RECORD_ID":10}]}
+}
+
+func ExampleNormalizeLenient() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/lenient_test.go
+	jsonText := `{
+		// Edited by hand.
+		PIPELINE: {'CONFIGPATH': '/etc/opt/senzing',},
+	}`
+
+	normalizedJSON, err := jsonutil.NormalizeLenient(jsonText)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(normalizedJSON)
+	// Output: {"PIPELINE":{"CONFIGPATH":"/etc/opt/senzing"}}
+}
+
+func ExampleNormalizeLines() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/stream_test.go
+	ctx := context.TODO()
+	jsonLines := `{"surname": "Schmoe", "givenName": "Joe"}
+{"surname": "Doe", "givenName": "Jane"}
+`
+
+	err := jsonutil.NormalizeLines(ctx, strings.NewReader(jsonLines), os.Stdout, jsonutil.StreamOptions{
+		MaxLineSize: 0,
+		Workers:     4,
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// {"givenName":"Joe","surname":"Schmoe"}
+	// {"givenName":"Jane","surname":"Doe"}
+}
+
+func ExampleParseAs() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/typed_test.go
+	type Record struct {
+		DataSource string `json:"DATA_SOURCE"`
+		RecordID   string `json:"RECORD_ID"`
+	}
+
+	record, err := jsonutil.ParseAs[Record](`{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}`)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(record.DataSource, record.RecordID)
+	// Output: CUSTOMERS 1001
+}
+
+func ExampleParseDocument() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/query_test.go
+	response := `{"RESOLVED_ENTITY": {"ENTITY_ID": 1, "RECORDS": [{"RECORD_ID": "1001"}, {"RECORD_ID": "1002"}]}}`
+
+	document, err := jsonutil.ParseDocument(response)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	entityID, err := document.GetInt64("$.RESOLVED_ENTITY.ENTITY_ID", 0)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	recordIDs, err := document.GetStrings("$.RESOLVED_ENTITY.RECORDS[*].RECORD_ID")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(entityID, recordIDs)
+	// Output: 1 [1001 1002]
+}
+
 func ExamplePrettyPrint() {
 	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/jsonutil_test.go
 	jsonText := `{"givenName": "Don","surname": "Juan","age": 52,"member": true,"ssn": "111-22-3333"}`
@@ -93,6 +394,27 @@ func ExamplePrettyPrint() {
 	// }
 }
 
+func ExamplePseudonymize() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/pseudonym_test.go
+	key := []byte("an example key; use 32 random bytes")
+	jsonText := `[{"RECORD_ID": "1", "SSN_NUMBER": "294-66-9999"}, {"RECORD_ID": "2", "SSN_NUMBER": "294-66-9999"}]`
+
+	pseudonymizer, err := jsonutil.Pseudonymize(key, true)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	redactedJSON, err := jsonutil.RedactWithMap(jsonText, map[string]any{
+		"SSN_NUMBER": pseudonymizer,
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(redactedJSON)
+	// Output: [{"RECORD_ID":"1","SSN_NUMBER":"552-38-1386"},{"RECORD_ID":"2","SSN_NUMBER":"552-38-1386"}]
+}
+
 func ExampleRedact() {
 	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/jsonutil_test.go
 	jsonText := `
@@ -113,6 +435,32 @@ func ExampleRedact() {
 	// Output: {"age":46,"givenName":"Bill","member":true,"ssn":null,"surname":"Jackson"}
 }
 
+func ExampleRedactPII() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/pii_test.go
+	jsonText := `{"NAME": "Joe Schmoe", "NOTES": "SSN 294-66-9999, call 702-919-1300", "CONTACT": "joe@example.com"}`
+
+	redactedJSON, err := jsonutil.RedactPII(jsonText, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(redactedJSON)
+	// Output: {"CONTACT":"[EMAIL]","NAME":"Joe Schmoe","NOTES":"SSN [SSN], call [PHONE]"}
+}
+
+func ExampleRedactPaths() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/selector_test.go
+	jsonText := `{"NAME": "Joe Schmoe", "RECORDS": [{"NAME": "Joe", "SSN": "111-22-3333"}, {"NAME": "Joey"}]}`
+
+	redactedJSON, err := jsonutil.RedactPaths(jsonText, "$.RECORDS[*].SSN", "$.RECORDS[1].NAME")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(redactedJSON)
+	// Output: {"NAME":"Joe Schmoe","RECORDS":[{"NAME":"Joe","SSN":null},{"NAME":null}]}
+}
+
 func ExampleRedactWithMap() {
 	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/jsonutil_test.go
 	jsonText := `
@@ -141,6 +489,22 @@ func ExampleReverseString() {
 	// Output: }"ateb" :"ahpla"{
 }
 
+func ExampleRoundTrip() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/typed_test.go
+	type Record struct {
+		DataSource string `json:"DATA_SOURCE"`
+		RecordID   string `json:"RECORD_ID"`
+	}
+
+	jsonText, err := jsonutil.RoundTrip[Record](`{"RECORD_ID": "1001", "DATA_SOURCE": "CUSTOMERS", "NAME_FULL": "Robert Smith"}`)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(jsonText)
+	// Output: {"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}
+}
+
 func ExampleStrip() {
 	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/jsonutil_test.go
 	jsonText := `
@@ -161,6 +525,52 @@ func ExampleStrip() {
 	// Output: {"age":35,"givenName":"Joe","member":true,"surname":"Schmoe"}
 }
 
+func ExampleStripPaths() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/selector_test.go
+	jsonText := `{"NAME": "Joe Schmoe", "JSON_DATA": {"SSN_NUMBER": "111-22-3333", "SSN_LAST4": "3333", "NAME": "Joe"}}`
+
+	strippedJSON, err := jsonutil.StripPaths(jsonText, "$..JSON_DATA./^SSN_/")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(strippedJSON)
+	// Output: {"JSON_DATA":{"NAME":"Joe"},"NAME":"Joe Schmoe"}
+}
+
+func ExampleSyntaxError() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/syntaxerror_test.go
+	jsonText := `{"RECORD_ID": "1001" "NAME_FULL": "Robert Smith"}`
+
+	var syntaxError *jsonutil.SyntaxError
+
+	_, err := jsonutil.Normalize(jsonText)
+	if errors.As(err, &syntaxError) {
+		fmt.Printf("line %d, column %d:\n%s\n", syntaxError.Line, syntaxError.Column, syntaxError.Excerpt)
+	}
+	// Output:
+	// line 1, column 22:
+	// {"RECORD_ID": "1001" "NAME_FULL": "Robert Smith"}
+	//                      ^
+}
+
+func ExampleTOMLToJSON() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/toml_test.go
+	tomlText := `
+[PIPELINE]
+SUPPORTPATH = "/opt/senzing/data"
+CONFIGPATH = "/etc/opt/senzing"
+`
+
+	jsonText, err := jsonutil.TOMLToJSON(tomlText)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(jsonText)
+	// Output: {"PIPELINE":{"SUPPORTPATH":"/opt/senzing/data","CONFIGPATH":"/etc/opt/senzing"}}
+}
+
 func ExampleTruncate() {
 	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/jsonutil_test.go
 	jsonText := `
@@ -175,3 +585,60 @@ func ExampleTruncate() {
 	fmt.Println(jsonutil.Truncate(jsonText, 5, "age"))
 	// Output: {"givenName":"Joe","member":true,"ssn":"111-22-3333","surname":"Schmoe"...
 }
+
+func ExampleTruncateWithOptions() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/truncate_test.go
+	jsonText := `{"NAME": "Robert", "NOTES": "Lorem ipsum dolor sit amet", "SCORES": [100, 99, 98, 97, 96]}`
+	options := jsonutil.TruncateOptions{MaxArrayItems: 2, MaxBytes: 0, MaxDepth: 0, MaxNodes: 0, MaxStringLength: 11}
+
+	truncatedJSON, err := jsonutil.TruncateWithOptions(jsonText, options)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(truncatedJSON)
+	// Output: {"NAME":"Robert","NOTES":"Lorem ipsum…(15 more characters)","SCORES":[100,99,"…(3 more items)"]}
+}
+
+func ExampleUnflatten() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/flatten_test.go
+	jsonText := `{"ENTITY_ID": 1, "RECORDS[0].DATA_SOURCE": "CUSTOMERS", "RECORDS[0].RECORD_ID": "1001"}`
+
+	result, err := jsonutil.Unflatten(jsonText, jsonutil.FlattenOptions{
+		Arrays:    jsonutil.FlattenArraysBracketed,
+		Separator: ".",
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(result)
+	// Output: {"ENTITY_ID":1,"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}]}
+}
+
+func ExampleValidateStrict() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/validate_test.go
+	jsonText := `{"NAME_LAST": "Smith", "NAME_LAST": "Jones"}`
+
+	for _, issue := range jsonutil.ValidateStrict(jsonText, jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0}) {
+		fmt.Println(issue)
+	}
+	// Output: line 1, column 24 (offset 23): duplicate key "NAME_LAST" (first at line 1, column 2)
+}
+
+func ExampleYAMLToJSON() {
+	// For more information, visit https://github.com/senzing-garage/go-helpers/blob/main/jsonutil/yaml_test.go
+	yamlText := `
+PIPELINE:
+  SUPPORTPATH: /opt/senzing/data
+  CONFIGPATH: /etc/opt/senzing
+`
+
+	jsonText, err := jsonutil.YAMLToJSON(yamlText)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(jsonText)
+	// Output: {"PIPELINE":{"SUPPORTPATH":"/opt/senzing/data","CONFIGPATH":"/etc/opt/senzing"}}
+}
@@ -32,6 +32,39 @@ const (
 
 var errFailed = errors.New("failed")
 
+// Numbers that float64 cannot represent exactly (2^53 + 1 and beyond), or would be rewritten.
+var testCasesForPreciseNumbers = []struct {
+	name     string
+	jsonText string
+	expected string
+}{
+	{
+		name:     "2^53+1",
+		jsonText: `{"RES_ENT_ID": 9007199254740993}`,
+		expected: `{"RES_ENT_ID":9007199254740993}`,
+	},
+	{
+		name:     "max-int64",
+		jsonText: `{"ENTITY_ID": 9223372036854775807, "NAME": "Joe"}`,
+		expected: `{"ENTITY_ID":9223372036854775807,"NAME":"Joe"}`,
+	},
+	{
+		name:     "max-uint64",
+		jsonText: `[18446744073709551615, -9223372036854775808]`,
+		expected: `[18446744073709551615,-9223372036854775808]`,
+	},
+	{
+		name:     "exponent-and-trailing-zero",
+		jsonText: `{"a": 1e21, "b": 1.50, "c": 0.000001}`,
+		expected: `{"a":1e21,"b":1.50,"c":0.000001}`,
+	},
+	{
+		name:     "number-literal",
+		jsonText: ` 12345678901234567890 `,
+		expected: `12345678901234567890`,
+	},
+}
+
 var testCasesForIsJSON = []struct {
 	name     string
 	jsonText string
@@ -586,6 +619,28 @@ func TestNormalize_Formatted(test *testing.T) {
 	assert.Equal(test, expected, actual, "JSON object (formatted) not normalized as expected")
 }
 
+func TestNormalize_PreciseNumbers(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForPreciseNumbers {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.Normalize(testCase.jsonText)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestNormalize_TrailingText(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.Normalize(`{"foo": 123} {"bar": 456}`)
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), "after top-level value")
+}
+
 // ----------------------------------------------------------------------------
 // Test NormalizeAndSortJson function
 // ----------------------------------------------------------------------------
@@ -624,6 +679,17 @@ func TestNormalizeAndSort_Formatted(test *testing.T) {
 	assert.Equal(test, expected, actual, "JSON object (formatted) not normalized as expected")
 }
 
+func TestNormalizeAndSort_PreciseNumbers(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"RECORDS": [9007199254740995, 9007199254740993, 18446744073709551615]}`
+	expected := `{"RECORDS":[18446744073709551615,9007199254740993,9007199254740995]}`
+
+	actual, err := jsonutil.NormalizeAndSort(jsonText)
+	require.NoError(test, err)
+	assert.Equal(test, expected, actual)
+}
+
 // ----------------------------------------------------------------------------
 // Test PrettyPrint function
 // ----------------------------------------------------------------------------
@@ -694,6 +760,13 @@ func TestPrettyPrint_UsingSpaces(test *testing.T) {
 	assert.Equal(test, expected, actual, "JSON object (formatted) not pretty printed as expected")
 }
 
+func TestPrettyPrint_BadJson(test *testing.T) {
+	test.Parallel()
+
+	actual := jsonutil.PrettyPrint(badJSON, "    ")
+	assert.Equal(test, badJSON, actual)
+}
+
 // ----------------------------------------------------------------------------
 // Test RedactJson function
 // ----------------------------------------------------------------------------
@@ -774,6 +847,17 @@ func TestRedact_Formatted4(test *testing.T) {
 	assert.Equal(test, expected, actual, "JSON object (formatted 4) not redacted as expected")
 }
 
+func TestRedact_PreciseNumbers(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"ENTITY_ID": 9007199254740993, "SSN": 123456789}`
+	expected := `{"ENTITY_ID":9007199254740993,"SSN":null}`
+
+	actual, err := jsonutil.Redact(jsonText, "SSN")
+	require.NoError(test, err)
+	assert.Equal(test, expected, actual)
+}
+
 // ----------------------------------------------------------------------------
 // Test RedactWithMap function
 // ----------------------------------------------------------------------------
@@ -975,6 +1059,17 @@ func TestStrip_Formatted4(test *testing.T) {
 	assert.Equal(test, expected, actual, "JSON object (formatted 4) not stripped as expected")
 }
 
+func TestStrip_PreciseNumbers(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"ENTITY_ID": 9007199254740993, "RECORDS": [{"RES_ENT_ID": 9223372036854775807, "SSN": 1}]}`
+	expected := `{"ENTITY_ID":9007199254740993,"RECORDS":[{"RES_ENT_ID":9223372036854775807}]}`
+
+	actual, err := jsonutil.Strip(jsonText, "SSN")
+	require.NoError(test, err)
+	assert.Equal(test, expected, actual)
+}
+
 // ----------------------------------------------------------------------------
 // Test Truncate function
 // ----------------------------------------------------------------------------
@@ -1009,6 +1104,16 @@ func TestTruncate_6_lines(test *testing.T) {
 	assert.Equal(test, expected, actual)
 }
 
+func TestTruncate_PreciseNumbers(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"ENTITY_ID": 9007199254740993, "SSN": 1}`
+	expected := `{"ENTITY_ID":9007199254740993}`
+
+	actual := jsonutil.Truncate(jsonText, 0, "SSN")
+	assert.Equal(test, expected, actual)
+}
+
 func TestTruncate_bad_JSON(test *testing.T) {
 	test.Parallel()
 
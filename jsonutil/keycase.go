@@ -0,0 +1,282 @@
+package jsonutil
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The ConvertKey function converts one key to a naming convention.
+The key is split into words at "_", "-", ".", and spaces, and where case changes
+(e.g. "entityId" and "HTTPServer" are two words each). Digits stay with the word before them,
+and a single lower case letter stays with the upper case letters before it
+(e.g. "IPv4Address" is "IPv4" and "Address", so it becomes "IPV4_ADDRESS" with KeyCaseUpperSnake).
+
+Input
+  - key: The key to be converted (e.g. "ENTITY_ID").
+  - keyCase: The naming convention (e.g. KeyCaseCamel).
+
+Output
+  - The converted key (e.g. "entityId").
+*/
+func ConvertKey(key string, keyCase KeyCase) string {
+	words := splitKey(key)
+
+	for index, word := range words {
+		switch {
+		case keyCase == KeyCaseUpperSnake:
+			words[index] = strings.ToUpper(word)
+		case keyCase == KeyCasePascal, keyCase == KeyCaseCamel && index > 0:
+			runes := []rune(strings.ToLower(word))
+			runes[0] = unicode.ToUpper(runes[0])
+			words[index] = string(runes)
+		default:
+			words[index] = strings.ToLower(word)
+		}
+	}
+
+	switch keyCase {
+	case KeyCaseKebab:
+		return strings.Join(words, "-")
+	case KeyCaseLowerSnake, KeyCaseUpperSnake:
+		return strings.Join(words, "_")
+	default:
+		return strings.Join(words, "")
+	}
+}
+
+/*
+The ConvertKeyCase function converts every key in JSON, at any depth, to a naming convention (see [ConvertKey]).
+For example, Senzing's "RESOLVED_ENTITY" becomes "resolvedEntity" with KeyCaseCamel.
+Member order and values are kept.
+
+Converting keys back may not restore them (e.g. "ENTITY_ID" and "entity_id" both become "entityId").
+To convert keys and back exactly, use [KeyCaseMapping], [RenameKeys], and [ReverseKeyMapping].
+
+Input
+  - jsonText: The JSON text whose keys are converted.
+  - keyCase: The naming convention.
+
+Output
+  - The JSON text with converted keys, compact.
+  - An error if the text is not JSON, or two keys of an object would become the same key.
+*/
+func ConvertKeyCase(jsonText string, keyCase KeyCase) (string, error) {
+	return renameKeys(jsonText, func(key string) string {
+		return ConvertKey(key, keyCase)
+	})
+}
+
+/*
+The KeyCaseMapping function returns a mapping from every key in JSON, at any depth,
+to the key in a naming convention (see [ConvertKey]).
+Use it with [RenameKeys] to convert the keys, and with [ReverseKeyMapping] to convert them back.
+
+	mapping, err := jsonutil.KeyCaseMapping(senzingJSON, jsonutil.KeyCaseCamel)
+	...
+	apiJSON, err := jsonutil.RenameKeys(senzingJSON, mapping)
+	...
+	reverseMapping, err := jsonutil.ReverseKeyMapping(mapping)
+	...
+	senzingJSON, err = jsonutil.RenameKeys(apiJSON, reverseMapping)
+
+Input
+  - jsonText: The JSON text whose keys are mapped.
+  - keyCase: The naming convention.
+
+Output
+  - The mapping, from original key to converted key.
+  - An error if the text is not JSON, or two different keys would become the same key,
+    so that the mapping could not be reversed.
+*/
+func KeyCaseMapping(jsonText string, keyCase KeyCase) (map[string]string, error) {
+	node, err := parseOrdered(jsonText)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]string{}
+	originals := map[string]string{} // Original key, by converted key.
+
+	for _, key := range collectKeys(node, []string{}) {
+		if _, isMapped := result[key]; isMapped {
+			continue
+		}
+
+		converted := ConvertKey(key, keyCase)
+
+		original, isConverted := originals[converted]
+		if isConverted {
+			return nil, wraperror.Errorf(errForPackage, "keys %s and %s both become %s", original, key, converted)
+		}
+
+		result[key] = converted
+		originals[converted] = key
+	}
+
+	return result, nil
+}
+
+/*
+The RenameKeys function renames keys in JSON, at any depth, using a mapping table.
+Keys that are not in the mapping are kept.
+Member order and values are kept.
+
+Input
+  - jsonText: The JSON text whose keys are renamed.
+  - mapping: The new name of each key to be renamed (e.g. {"RECORD_ID": "recordId"}).
+
+Output
+  - The JSON text with renamed keys, compact.
+  - An error if the text is not JSON, or two keys of an object would have the same name.
+*/
+func RenameKeys(jsonText string, mapping map[string]string) (string, error) {
+	return renameKeys(jsonText, func(key string) string {
+		newKey, isMapped := mapping[key]
+		if isMapped {
+			return newKey
+		}
+
+		return key
+	})
+}
+
+/*
+The ReverseKeyMapping function reverses a mapping of keys (e.g. from [KeyCaseMapping]),
+so that [RenameKeys] can restore the original keys.
+
+Input
+  - mapping: The mapping to be reversed.
+
+Output
+  - The reversed mapping.
+  - An error if two keys map to the same key, so that the mapping cannot be reversed.
+*/
+func ReverseKeyMapping(mapping map[string]string) (map[string]string, error) {
+	result := make(map[string]string, len(mapping))
+
+	for _, key := range slices.Sorted(maps.Keys(mapping)) {
+		original, isReversed := result[mapping[key]]
+		if isReversed {
+			return nil, wraperror.Errorf(errForPackage, "keys %s and %s both map to %s", original, key, mapping[key])
+		}
+
+		result[mapping[key]] = key
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Append the keys of every object in the node to result, in document order.
+func collectKeys(node formatNode, result []string) []string {
+	result = append(result, node.keys...)
+
+	for _, child := range node.children {
+		result = collectKeys(child, result)
+	}
+
+	return result
+}
+
+// Whether a key is split into words between the runes at index-1 and index.
+func isWordBoundary(runes []rune, index int) bool {
+	previous, current := runes[index-1], runes[index]
+
+	switch {
+	case !unicode.IsUpper(current):
+		return false
+	case unicode.IsLower(previous) || unicode.IsDigit(previous):
+		return true // e.g. "entity|Id", "line1|Text".
+	default:
+		// "HTTP|Server", but not "I|Pv4" or "UR|Ls": one lower case letter is part of the acronym.
+		return unicode.IsUpper(previous) && index+2 < len(runes) &&
+			unicode.IsLower(runes[index+1]) && unicode.IsLower(runes[index+2])
+	}
+}
+
+// Rename every key with rename, checking that keys stay unique within each object.
+func renameKeys(jsonText string, rename func(string) string) (string, error) {
+	node, err := parseOrdered(jsonText)
+	if err != nil {
+		return jsonText, err
+	}
+
+	err = renameNodeKeys(&node, "$", rename)
+	if err != nil {
+		return jsonText, err
+	}
+
+	var builder strings.Builder
+
+	writeCompact(&builder, node)
+
+	return builder.String(), nil
+}
+
+func renameNodeKeys(node *formatNode, path string, rename func(string) string) error {
+	originals := make(map[string]string, len(node.keys)) // Original key, by new key.
+
+	for index := range node.children {
+		if node.kind == '[' {
+			err := renameNodeKeys(&node.children[index], appendIndex(path, index), rename)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		key := node.keys[index]
+		newKey := rename(key)
+
+		original, isRenamed := originals[newKey]
+		if isRenamed {
+			return wraperror.Errorf(errForPackage, "at %s, keys %s and %s both become %s", path, original, key, newKey)
+		}
+
+		originals[newKey] = key
+		node.keys[index] = newKey
+
+		err := renameNodeKeys(&node.children[index], appendKey(path, key), rename)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Split a key into words. See ConvertKey.
+func splitKey(key string) []string {
+	result := []string{}
+
+	for _, part := range strings.FieldsFunc(key, func(character rune) bool {
+		return character == '_' || character == '-' || character == '.' || unicode.IsSpace(character)
+	}) {
+		runes := []rune(part)
+		start := 0
+
+		for index := 1; index < len(runes); index++ {
+			if isWordBoundary(runes, index) {
+				result = append(result, string(runes[start:index]))
+				start = index
+			}
+		}
+
+		result = append(result, string(runes[start:]))
+	}
+
+	return result
+}
@@ -0,0 +1,223 @@
+package jsonutil_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonTextForKeyCase = `{"RESOLVED_ENTITY": {"ENTITY_ID": 1, "RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "ADDR_LINE1": "123 Main St"}]}}`
+
+var testCasesForConvertKey = []struct {
+	key        string
+	camel      string
+	kebab      string
+	lowerSnake string
+	pascal     string
+	upperSnake string
+}{
+	{key: "ENTITY_ID", camel: "entityId", kebab: "entity-id", lowerSnake: "entity_id", pascal: "EntityId", upperSnake: "ENTITY_ID"},
+	{key: "entityId", camel: "entityId", kebab: "entity-id", lowerSnake: "entity_id", pascal: "EntityId", upperSnake: "ENTITY_ID"},
+	{key: "ADDR_LINE1", camel: "addrLine1", kebab: "addr-line1", lowerSnake: "addr_line1", pascal: "AddrLine1", upperSnake: "ADDR_LINE1"},
+	{key: "addrLine1", camel: "addrLine1", kebab: "addr-line1", lowerSnake: "addr_line1", pascal: "AddrLine1", upperSnake: "ADDR_LINE1"},
+	{key: "HTTPServer", camel: "httpServer", kebab: "http-server", lowerSnake: "http_server", pascal: "HttpServer", upperSnake: "HTTP_SERVER"},
+	{key: "HTTP_SERVER", camel: "httpServer", kebab: "http-server", lowerSnake: "http_server", pascal: "HttpServer", upperSnake: "HTTP_SERVER"},
+	{key: "IPv4Address", camel: "ipv4Address", kebab: "ipv4-address", lowerSnake: "ipv4_address", pascal: "Ipv4Address", upperSnake: "IPV4_ADDRESS"},
+	{key: "IPV4_ADDRESS", camel: "ipv4Address", kebab: "ipv4-address", lowerSnake: "ipv4_address", pascal: "Ipv4Address", upperSnake: "IPV4_ADDRESS"},
+	{key: "ID2", camel: "id2", kebab: "id2", lowerSnake: "id2", pascal: "Id2", upperSnake: "ID2"},
+	{key: "ID2Name", camel: "id2Name", kebab: "id2-name", lowerSnake: "id2_name", pascal: "Id2Name", upperSnake: "ID2_NAME"},
+	{key: "recordIDs", camel: "recordIds", kebab: "record-ids", lowerSnake: "record_ids", pascal: "RecordIds", upperSnake: "RECORD_IDS"},
+	{key: "XMLHttpRequest", camel: "xmlHttpRequest", kebab: "xml-http-request", lowerSnake: "xml_http_request", pascal: "XmlHttpRequest", upperSnake: "XML_HTTP_REQUEST"},
+	{key: "line1Text", camel: "line1Text", kebab: "line1-text", lowerSnake: "line1_text", pascal: "Line1Text", upperSnake: "LINE1_TEXT"},
+	{key: "name-full", camel: "nameFull", kebab: "name-full", lowerSnake: "name_full", pascal: "NameFull", upperSnake: "NAME_FULL"},
+	{key: "Name Full", camel: "nameFull", kebab: "name-full", lowerSnake: "name_full", pascal: "NameFull", upperSnake: "NAME_FULL"},
+	{key: "__ID__", camel: "id", kebab: "id", lowerSnake: "id", pascal: "Id", upperSnake: "ID"},
+	{key: "ÉCOLE_NAME", camel: "écoleName", kebab: "école-name", lowerSnake: "école_name", pascal: "ÉcoleName", upperSnake: "ÉCOLE_NAME"},
+	{key: "", camel: "", kebab: "", lowerSnake: "", pascal: "", upperSnake: ""},
+}
+
+var testCasesForConvertKeyCase = []struct {
+	name     string
+	keyCase  jsonutil.KeyCase
+	expected string
+}{
+	{
+		name:     "camel",
+		keyCase:  jsonutil.KeyCaseCamel,
+		expected: `{"resolvedEntity":{"entityId":1,"records":[{"dataSource":"CUSTOMERS","addrLine1":"123 Main St"}]}}`,
+	},
+	{
+		name:     "kebab",
+		keyCase:  jsonutil.KeyCaseKebab,
+		expected: `{"resolved-entity":{"entity-id":1,"records":[{"data-source":"CUSTOMERS","addr-line1":"123 Main St"}]}}`,
+	},
+	{
+		name:     "lower-snake",
+		keyCase:  jsonutil.KeyCaseLowerSnake,
+		expected: `{"resolved_entity":{"entity_id":1,"records":[{"data_source":"CUSTOMERS","addr_line1":"123 Main St"}]}}`,
+	},
+	{
+		name:     "pascal",
+		keyCase:  jsonutil.KeyCasePascal,
+		expected: `{"ResolvedEntity":{"EntityId":1,"Records":[{"DataSource":"CUSTOMERS","AddrLine1":"123 Main St"}]}}`,
+	},
+	{
+		name:     "upper-snake",
+		keyCase:  jsonutil.KeyCaseUpperSnake,
+		expected: `{"RESOLVED_ENTITY":{"ENTITY_ID":1,"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","ADDR_LINE1":"123 Main St"}]}}`,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestConvertKey(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForConvertKey {
+		test.Run(testCase.key, func(test *testing.T) {
+			test.Parallel()
+			assert.Equal(test, testCase.camel, jsonutil.ConvertKey(testCase.key, jsonutil.KeyCaseCamel))
+			assert.Equal(test, testCase.kebab, jsonutil.ConvertKey(testCase.key, jsonutil.KeyCaseKebab))
+			assert.Equal(test, testCase.lowerSnake, jsonutil.ConvertKey(testCase.key, jsonutil.KeyCaseLowerSnake))
+			assert.Equal(test, testCase.pascal, jsonutil.ConvertKey(testCase.key, jsonutil.KeyCasePascal))
+			assert.Equal(test, testCase.upperSnake, jsonutil.ConvertKey(testCase.key, jsonutil.KeyCaseUpperSnake))
+		})
+	}
+}
+
+func TestConvertKeyCase(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForConvertKeyCase {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.ConvertKeyCase(jsonTextForKeyCase, testCase.keyCase)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestConvertKeyCase_badJSON(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.ConvertKeyCase(badJSON, jsonutil.KeyCaseCamel)
+	require.Error(test, err)
+	assert.Equal(test, badJSON, actual)
+}
+
+func TestConvertKeyCase_collision(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"RECORDS": [{"RECORD_ID": "1001", "recordId": "1002"}]}`
+
+	actual, err := jsonutil.ConvertKeyCase(jsonText, jsonutil.KeyCaseCamel)
+	require.Error(test, err)
+	assert.Equal(test, jsonText, actual)
+	assert.Contains(test, err.Error(), `at $.RECORDS[0], keys RECORD_ID and recordId both become recordId`)
+	assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+}
+
+func TestConvertKeyCase_scalar(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.ConvertKeyCase(`"ENTITY_ID"`, jsonutil.KeyCaseCamel)
+	require.NoError(test, err)
+	assert.Equal(test, `"ENTITY_ID"`, actual)
+}
+
+func TestKeyCaseMapping(test *testing.T) {
+	test.Parallel()
+
+	mapping, err := jsonutil.KeyCaseMapping(jsonTextForKeyCase, jsonutil.KeyCaseCamel)
+	require.NoError(test, err)
+	assert.Equal(test, map[string]string{
+		"ADDR_LINE1":      "addrLine1",
+		"DATA_SOURCE":     "dataSource",
+		"ENTITY_ID":       "entityId",
+		"RECORDS":         "records",
+		"RESOLVED_ENTITY": "resolvedEntity",
+	}, mapping)
+}
+
+func TestKeyCaseMapping_ambiguous(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.KeyCaseMapping(`[{"ENTITY_ID": 1}, {"entity_id": 2}]`, jsonutil.KeyCaseCamel)
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), `keys ENTITY_ID and entity_id both become entityId`)
+	assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+}
+
+func TestKeyCaseMapping_badJSON(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.KeyCaseMapping(badJSON, jsonutil.KeyCaseCamel)
+	require.Error(test, err)
+}
+
+func TestKeyCaseMapping_roundTrip(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"ENTITY_ID":1,"RECORDS":[{"RecordId":"1001","record-type":"PERSON"}],"entityName":"Robert Smith"}`
+
+	mapping, err := jsonutil.KeyCaseMapping(jsonText, jsonutil.KeyCaseLowerSnake)
+	require.NoError(test, err)
+
+	converted, err := jsonutil.RenameKeys(jsonText, mapping)
+	require.NoError(test, err)
+	assert.Equal(test, `{"entity_id":1,"records":[{"record_id":"1001","record_type":"PERSON"}],"entity_name":"Robert Smith"}`,
+		converted)
+
+	reverseMapping, err := jsonutil.ReverseKeyMapping(mapping)
+	require.NoError(test, err)
+
+	restored, err := jsonutil.RenameKeys(converted, reverseMapping)
+	require.NoError(test, err)
+	assert.Equal(test, jsonText, restored)
+}
+
+func TestRenameKeys(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.RenameKeys(jsonTextForKeyCase, map[string]string{
+		"DATA_SOURCE": "source",
+		"ENTITY_ID":   "id",
+		"NOT_THERE":   "missing",
+	})
+	require.NoError(test, err)
+	assert.Equal(test,
+		`{"RESOLVED_ENTITY":{"id":1,"RECORDS":[{"source":"CUSTOMERS","ADDR_LINE1":"123 Main St"}]}}`, actual)
+}
+
+func TestRenameKeys_collision(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.RenameKeys(`{"A": {"B": 1, "C": 2}}`, map[string]string{"B": "C"})
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), `at $.A, keys B and C both become C`)
+	assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+}
+
+func TestRenameKeys_swap(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.RenameKeys(`{"A": 1, "B": 2}`, map[string]string{"A": "B", "B": "A"})
+	require.NoError(test, err)
+	assert.Equal(test, `{"B":1,"A":2}`, actual)
+}
+
+func TestReverseKeyMapping_notReversible(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.ReverseKeyMapping(map[string]string{"ENTITY_ID": "id", "RECORD_ID": "id"})
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), `keys ENTITY_ID and RECORD_ID both map to id`)
+	assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+}
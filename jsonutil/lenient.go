@@ -0,0 +1,497 @@
+package jsonutil
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// A lenient number: an optional sign, then hexadecimal digits, or decimal digits with an optional exponent,
+// where the digits before or after the decimal point may be left out (e.g. ".5", "5.").
+var lenientNumberPattern = regexp.MustCompile(
+	`^([+-]?)(?:0[xX]([0-9a-fA-F]+)|(0|[1-9][0-9]*)?(?:\.([0-9]*))?([eE][+-]?[0-9]+)?)$`,
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A lenientParser converts JSONC or JSON5-style text to strict, compact JSON, keeping the order of members.
+type lenientParser struct {
+	builder strings.Builder
+	depth   int
+	offset  int
+	text    string
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NormalizeLenient function normalizes hand-edited JSON (see [Normalize]), accepting the
+JSONC and JSON5 extensions that people commonly use:
+
+  - Comments, from // to the end of the line, and block comments, as in C and Go.
+  - A comma after the last element of an array or member of an object.
+  - Strings in single quotes (e.g. 'Robert'), and object keys without quotes (e.g. {PIPELINE: {}}).
+  - JSON5 escapes in strings (e.g. \x41, \', and a backslash at the end of a line to continue the string).
+  - Hexadecimal numbers (e.g. 0x1F), a leading "+", and a leading or trailing decimal point (e.g. .5 and 5.).
+
+Infinity and NaN are not accepted, as they cannot be written in JSON.
+Strict JSON is accepted too, so NormalizeLenient can be used wherever JSON may have been edited by hand
+(e.g. SENZING_TOOLS_ENGINE_CONFIGURATION_JSON).
+
+Input
+  - jsonText: The lenient JSON text to be normalized.
+
+Output
+  - The strict JSON text that is the normalized representation of the specified text.
+  - An error, with a [*SyntaxError] locating the problem in jsonText, if the text is not lenient JSON.
+*/
+func NormalizeLenient(jsonText string) (string, error) {
+	parser := &lenientParser{
+		builder: strings.Builder{},
+		depth:   0,
+		offset:  0,
+		text:    jsonText,
+	}
+
+	err := parser.parse()
+	if err != nil {
+		return jsonText, wraperror.Errorf(err, "parse")
+	}
+
+	return Normalize(parser.builder.String())
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Convert an array, starting at its "[".
+func (parser *lenientParser) array() error {
+	return parser.container(']', func() error {
+		return parser.value()
+	})
+}
+
+/*
+Convert an array or object, starting at its opening delimiter, calling convertElement for each element or member.
+A comma may follow the last one.
+*/
+func (parser *lenientParser) container(closing byte, convertElement func() error) error {
+	parser.depth++
+	if parser.depth > DefaultMaxDepth {
+		return syntaxErrorAt(parser.text, parser.offset,
+			fmt.Sprintf("arrays and objects nested more than %d deep", DefaultMaxDepth), nil)
+	}
+
+	parser.builder.WriteByte(parser.text[parser.offset])
+	parser.offset++
+
+	for count := 0; ; count++ {
+		err := parser.skipIgnored()
+		if err != nil {
+			return err
+		}
+
+		if parser.peek() == closing {
+			parser.builder.WriteByte(closing)
+			parser.offset++
+			parser.depth--
+
+			return nil
+		}
+
+		if count > 0 {
+			parser.builder.WriteByte(',')
+		}
+
+		err = convertElement()
+		if err != nil {
+			return err
+		}
+
+		err = parser.skipIgnored()
+		if err != nil {
+			return err
+		}
+
+		switch parser.peek() {
+		case ',':
+			parser.offset++
+		case closing:
+		default:
+			if closing == ']' {
+				return parser.syntaxError("after array element")
+			}
+
+			return parser.syntaxError("after object key:value pair")
+		}
+	}
+}
+
+// An invalid escape sequence in a string, in the words of encoding/json.
+func (parser *lenientParser) escapeError(escapeOffset int, end int) error {
+	return syntaxErrorAt(parser.text, escapeOffset,
+		fmt.Sprintf("invalid escape sequence `%s` in string", parser.text[escapeOffset:min(end, len(parser.text))]), nil)
+}
+
+// Convert the hexadecimal digits of a \x or \u escape, and the low surrogate that may follow a high surrogate.
+func (parser *lenientParser) hexEscape(result *strings.Builder, escapeOffset int, digitCount int) error {
+	if parser.offset+digitCount > len(parser.text) {
+		return parser.escapeError(escapeOffset, parser.offset+digitCount)
+	}
+
+	codeUnit, err := strconv.ParseUint(parser.text[parser.offset:parser.offset+digitCount], hexBase, 32)
+	if err != nil {
+		return parser.escapeError(escapeOffset, parser.offset+digitCount)
+	}
+
+	parser.offset += digitCount
+
+	if utf16.IsSurrogate(rune(codeUnit)) && codeUnit < firstLowSurrogate &&
+		strings.HasPrefix(parser.text[parser.offset:], `\u`) && parser.offset+len(`\u0000`) <= len(parser.text) {
+		lowCodeUnit, err := strconv.ParseUint(parser.text[parser.offset+len(`\u`):parser.offset+len(`\u0000`)],
+			hexBase, 32)
+		if err == nil {
+			decoded := utf16.DecodeRune(rune(codeUnit), rune(lowCodeUnit))
+			if decoded != utf8.RuneError {
+				parser.offset += len(`\u0000`)
+				result.WriteRune(decoded)
+
+				return nil
+			}
+		}
+	}
+
+	result.WriteRune(rune(codeUnit))
+
+	return nil
+}
+
+// Scan an identifier: true, false, null, or an object key without quotes.
+func (parser *lenientParser) identifier() string {
+	start := parser.offset
+
+	for parser.offset < len(parser.text) {
+		character, size := utf8.DecodeRuneInString(parser.text[parser.offset:])
+		if character != '_' && character != '$' && !unicode.IsLetter(character) && !unicode.IsDigit(character) {
+			break
+		}
+
+		parser.offset += size
+	}
+
+	return parser.text[start:parser.offset]
+}
+
+// Convert a number, in any of the lenient forms.
+func (parser *lenientParser) number() error {
+	start := parser.offset
+
+	for parser.offset < len(parser.text) && (isDigit(parser.text[parser.offset]) ||
+		strings.IndexByte("+-.abcdefABCDEFxX", parser.text[parser.offset]) >= 0) {
+		parser.offset++
+	}
+
+	number, isNumber := strictNumber(parser.text[start:parser.offset])
+	if !isNumber {
+		return syntaxErrorAt(parser.text, start,
+			fmt.Sprintf("invalid numeric literal `%s`", parser.text[start:parser.offset]), nil)
+	}
+
+	parser.builder.WriteString(number)
+
+	return nil
+}
+
+// Convert a number, or report a signed Infinity or NaN.
+func (parser *lenientParser) numberOrSpecial() error {
+	rest := strings.TrimLeft(parser.text[parser.offset:], "+-")
+	if strings.HasPrefix(rest, "Infinity") || strings.HasPrefix(rest, "NaN") {
+		return syntaxErrorAt(parser.text, parser.offset, "Infinity and NaN cannot be written in JSON", nil)
+	}
+
+	return parser.number()
+}
+
+// Convert an object, starting at its "{".
+func (parser *lenientParser) object() error {
+	return parser.container('}', func() error {
+		var key string
+
+		switch character := parser.peek(); {
+		case character == '"' || character == '\'':
+			text, err := parser.string()
+			if err != nil {
+				return err
+			}
+
+			key = text
+		case isDigit(character):
+			return parser.syntaxError("looking for beginning of object key string")
+		default:
+			key = parser.identifier()
+			if key == "" {
+				return parser.syntaxError("looking for beginning of object key string")
+			}
+		}
+
+		parser.builder.WriteString(encodeString(key))
+
+		err := parser.skipIgnored()
+		if err != nil {
+			return err
+		}
+
+		if parser.peek() != ':' {
+			return parser.syntaxError("after object key")
+		}
+
+		parser.builder.WriteByte(':')
+		parser.offset++
+
+		return parser.value()
+	})
+}
+
+// Convert the whole text, which must be one value.
+func (parser *lenientParser) parse() error {
+	err := parser.value()
+	if err != nil {
+		return err
+	}
+
+	err = parser.skipIgnored()
+	if err != nil {
+		return err
+	}
+
+	if parser.offset < len(parser.text) {
+		return parser.syntaxError("after top-level value")
+	}
+
+	return nil
+}
+
+func (parser *lenientParser) peek() byte {
+	if parser.offset < len(parser.text) {
+		return parser.text[parser.offset]
+	}
+
+	return 0
+}
+
+// Skip whitespace and comments.
+func (parser *lenientParser) skipIgnored() error {
+	for parser.offset < len(parser.text) {
+		rest := parser.text[parser.offset:]
+		character, size := utf8.DecodeRuneInString(rest)
+
+		switch {
+		case unicode.IsSpace(character) || character == '\uFEFF':
+			parser.offset += size
+		case strings.HasPrefix(rest, "//"):
+			end := strings.IndexAny(rest, "\r\n")
+			if end < 0 {
+				end = len(rest)
+			}
+
+			parser.offset += end
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[len("/*"):], "*/")
+			if end < 0 {
+				return syntaxErrorAt(parser.text, parser.offset, "comment is not closed", nil)
+			}
+
+			parser.offset += len("/*") + end + len("*/")
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// Convert a string in double or single quotes, starting at the quote. Returns the decoded string.
+func (parser *lenientParser) string() (string, error) {
+	var result strings.Builder
+
+	quote := parser.text[parser.offset]
+	parser.offset++
+
+	for {
+		character := parser.peek()
+
+		switch {
+		case parser.offset >= len(parser.text):
+			return "", syntaxErrorAt(parser.text, parser.offset, "unexpected end of JSON input", nil)
+		case character == quote:
+			parser.offset++
+
+			return result.String(), nil
+		case character == '\\':
+			err := parser.stringEscape(&result)
+			if err != nil {
+				return "", err
+			}
+		case character == '\n' || character == '\r':
+			return "", parser.syntaxError("in string")
+		default:
+			result.WriteByte(character)
+			parser.offset++
+		}
+	}
+}
+
+// Convert an escape sequence in a string, appending the character, if any, to result.
+func (parser *lenientParser) stringEscape(result *strings.Builder) error {
+	escapeOffset := parser.offset
+	parser.offset++ // The backslash.
+
+	character, size := utf8.DecodeRuneInString(parser.text[parser.offset:])
+	parser.offset += size
+
+	switch character {
+	case utf8.RuneError:
+		if size == 0 {
+			return syntaxErrorAt(parser.text, parser.offset, "unexpected end of JSON input", nil)
+		}
+
+		result.WriteRune(character)
+	case 'b':
+		result.WriteByte('\b')
+	case 'f':
+		result.WriteByte('\f')
+	case 'n':
+		result.WriteByte('\n')
+	case 'r':
+		result.WriteByte('\r')
+	case 't':
+		result.WriteByte('\t')
+	case 'v':
+		result.WriteByte('\v')
+	case '0':
+		if isDigit(parser.peek()) {
+			return parser.escapeError(escapeOffset, parser.offset+1)
+		}
+
+		result.WriteByte(0)
+	case 'x':
+		return parser.hexEscape(result, escapeOffset, len("00"))
+	case 'u':
+		return parser.hexEscape(result, escapeOffset, len("0000"))
+	case '\n', '\u2028', '\u2029':
+		// A line continuation: the line break is not part of the string.
+	case '\r':
+		if parser.peek() == '\n' {
+			parser.offset++
+		}
+	default:
+		if isDigit(byte(character)) {
+			return parser.escapeError(escapeOffset, parser.offset)
+		}
+
+		result.WriteRune(character) // Any other character stands for itself (e.g. \' and \").
+	}
+
+	return nil
+}
+
+// A syntax error at the current offset, in the words of encoding/json.
+func (parser *lenientParser) syntaxError(context string) error {
+	if parser.offset >= len(parser.text) {
+		return syntaxErrorAt(parser.text, parser.offset, "unexpected end of JSON input", nil)
+	}
+
+	return syntaxErrorAt(parser.text, parser.offset,
+		fmt.Sprintf("invalid character %s %s", quoteCharacter(parser.text[parser.offset:]), context), nil)
+}
+
+// Convert any value.
+func (parser *lenientParser) value() error {
+	err := parser.skipIgnored()
+	if err != nil {
+		return err
+	}
+
+	character := parser.peek()
+
+	switch {
+	case parser.offset >= len(parser.text):
+		return parser.syntaxError("")
+	case character == '{':
+		return parser.object()
+	case character == '[':
+		return parser.array()
+	case character == '"' || character == '\'':
+		text, err := parser.string()
+		if err != nil {
+			return err
+		}
+
+		parser.builder.WriteString(encodeString(text))
+
+		return nil
+	case isDigit(character) || character == '-' || character == '+' || character == '.':
+		return parser.numberOrSpecial()
+	}
+
+	start := parser.offset
+
+	switch parser.identifier() {
+	case "true", "false", "null":
+		parser.builder.WriteString(parser.text[start:parser.offset])
+
+		return nil
+	case "Infinity", "NaN":
+		return syntaxErrorAt(parser.text, start, "Infinity and NaN cannot be written in JSON", nil)
+	}
+
+	parser.offset = start
+
+	return parser.syntaxError("looking for beginning of value")
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Convert a number in any of the lenient forms (e.g. "+.5", "0x1F") to a JSON number. Returns false if it is not one.
+func strictNumber(text string) (string, bool) {
+	match := lenientNumberPattern.FindStringSubmatch(text)
+	if match == nil || (match[2] == "" && match[3] == "" && match[4] == "") {
+		return "", false
+	}
+
+	sign, hexDigits, integer, fraction, exponent := match[1], match[2], match[3], match[4], match[5]
+
+	if sign != "-" {
+		sign = ""
+	}
+
+	if hexDigits != "" {
+		value, _ := new(big.Int).SetString(hexDigits, hexBase) // The pattern matched hexadecimal digits.
+
+		return sign + value.String(), true
+	}
+
+	if integer == "" {
+		integer = "0"
+	}
+
+	if fraction != "" {
+		fraction = "." + fraction
+	}
+
+	return sign + integer + fraction + exponent, true
+}
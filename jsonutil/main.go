@@ -1,2 +1,209 @@
 // The normalizejson package helps normalize JSON for canonical result comparison
 package jsonutil
+
+import "errors"
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A Transform converts one JSON value to another (e.g. Normalize, NormalizeAndSort).
+type Transform func(jsonText string) (string, error)
+
+// A Difference is one difference between two JSON documents, found by Diff.
+type Difference struct {
+	Kind DiffKind
+	New  any    // The value in the second document. Unused if Kind is DiffRemoved.
+	Old  any    // The value in the first document. Unused if Kind is DiffAdded.
+	Path string // Where the difference is, as a selector (e.g. "$.RECORDS[0].NAME"). See CompileSelector.
+}
+
+// A Document is parsed JSON that can be queried with selectors. See ParseDocument.
+type Document struct {
+	value any
+}
+
+// DiffKind identifies the kind of a Difference.
+type DiffKind string
+
+// DiffOptions controls the comparison made by Diff.
+type DiffOptions struct {
+	IgnoreArrayOrder bool     // If true, arrays with the same elements in a different order are equal.
+	IgnoreKeys       []string // JSON keys ignored anywhere in either document, as if removed by Strip.
+}
+
+// FlattenArrays says how FlattenObject and Unflatten represent the elements of arrays.
+type FlattenArrays int
+
+// FlattenOptions controls the keys made by FlattenObject and read by Unflatten.
+type FlattenOptions struct {
+	Arrays    FlattenArrays // How array elements are keyed, if at all.
+	Separator string        // Between the keys of nested values (e.g. "." or "_"). If empty, "." is used.
+}
+
+// FormatOptions controls the output of Format.
+type FormatOptions struct {
+	Color    bool   // If true, keys and values are colored with ANSI escape sequences.
+	Indent   string // Indentation for each level of nesting (e.g. "  " or "\t").
+	MaxWidth int    // If > 0, arrays that fit within this many columns are written on one line.
+	Prefix   string // Written at the start of every line after the first.
+	SortKeys bool   // If true, object members are sorted by key. Otherwise their order is kept.
+}
+
+// KeyCase identifies a naming convention for JSON keys, used by ConvertKeyCase.
+type KeyCase int
+
+// PIIAction says what RedactPII does with a detected value.
+type PIIAction int
+
+// PIIKind identifies a kind of personally identifiable information (PII) detected by FindPII and RedactPII.
+type PIIKind string
+
+// A PIIMatch is PII found in text by FindPII.
+type PIIMatch struct {
+	End   int     // Byte offset of the end of the match.
+	Kind  PIIKind // The kind of PII.
+	Start int     // Byte offset of the start of the match.
+	Value string  // The matched text.
+}
+
+// A PIIRule configures how RedactPII handles one kind of PII.
+type PIIRule struct {
+	Action           PIIAction
+	FormatPreserving bool   // Used by PIIPseudonymize. See Pseudonymize.
+	Key              []byte // Used by PIIPseudonymize. At least MinPseudonymKeyLength bytes. See Pseudonymize.
+	Replacement      string // Used by PIIReplace. If empty, "[KIND]" is used (e.g. "[SSN]").
+}
+
+// A RedactionFunc computes the redacted form of a JSON value (e.g. Pseudonymize).
+// It may be used as a value in the redaction maps of RedactWithMap and RedactPathsWithMap.
+type RedactionFunc func(jsonValue any) any
+
+// A Selector identifies values in a JSON document by path. See CompileSelector.
+type Selector struct {
+	steps []selectorStep
+	text  string
+}
+
+// SortOptions controls the sorting of arrays by NormalizeAndSortWithOptions.
+type SortOptions struct {
+	ByKeys []string // Keys whose values order the objects in sorted arrays (e.g. "DATA_SOURCE", "RECORD_ID").
+	Paths  []string // Selectors of the arrays to sort (see CompileSelector). If empty, every array is sorted.
+}
+
+// StreamOptions controls the processing of JSON lines by TransformLines and related functions.
+type StreamOptions struct {
+	MaxLineSize int // Longest line accepted, in bytes. If <= 0, DefaultMaxLineSize is used.
+	Workers     int // Number of lines transformed in parallel. If <= 1, lines are transformed one at a time.
+}
+
+/*
+A SyntaxError locates the error in text that is not JSON, for functions like Normalize and Redact.
+Use errors.As to get it from their errors.
+It unwraps to the *json.SyntaxError from encoding/json, if any.
+*/
+type SyntaxError struct {
+	Column  int    // Column of the error on its line, counting characters from 1.
+	Excerpt string // The text around the error on its line, then a line with a caret (^) under the error.
+	Line    int    // Line of the error, counting from 1.
+	Message string // A description of the error (e.g. "invalid character '}' after array element").
+	Offset  int    // Byte offset of the error from the start of the text.
+	err     error
+}
+
+// TruncateOptions limits the output of TruncateWithOptions. Limits that are zero or negative are not applied.
+type TruncateOptions struct {
+	MaxArrayItems   int // Most elements kept in each array.
+	MaxBytes        int // Longest output, in bytes.
+	MaxDepth        int // Deepest nesting of arrays and objects kept. The outermost array or object is at depth 1.
+	MaxNodes        int // Most values (including arrays and objects) kept.
+	MaxStringLength int // Longest string kept, in characters.
+}
+
+// ValidateOptions sets the limits checked by ValidateStrict.
+type ValidateOptions struct {
+	MaxBytes int // Longest text accepted, in bytes. If <= 0, any length is accepted.
+	MaxDepth int // Deepest nesting of arrays and objects accepted. If <= 0, DefaultMaxDepth is used.
+}
+
+// A ValidationIssue is a problem found in JSON text by ValidateStrict.
+type ValidationIssue struct {
+	Column  int            // Column of the problem on its line, counting characters from 1.
+	Kind    ValidationKind // The kind of problem.
+	Line    int            // Line of the problem, counting from 1.
+	Message string         // A description of the problem (e.g. `duplicate key "NAME_LAST"`).
+	Offset  int            // Byte offset of the problem from the start of the text.
+}
+
+// ValidationKind identifies the kind of a ValidationIssue.
+type ValidationKind string
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// DefaultMaxDepth is the deepest nesting accepted when ValidateOptions.MaxDepth is not set (as for encoding/json).
+const DefaultMaxDepth = 10000
+
+// DefaultMaxLineSize is the longest line accepted when StreamOptions.MaxLineSize is not set.
+const DefaultMaxLineSize = 16 * 1024 * 1024
+
+// MinPseudonymKeyLength is the shortest key, in bytes, accepted by Pseudonymize and by RedactPII for PIIPseudonymize.
+// Use a longer key (e.g. 32 random bytes) where possible.
+const MinPseudonymKeyLength = 16
+
+// Kinds of differences.
+const (
+	DiffAdded       DiffKind = "added"        // The value is only in the second document.
+	DiffChanged     DiffKind = "changed"      // The value has the same JSON type, but is different.
+	DiffRemoved     DiffKind = "removed"      // The value is only in the first document.
+	DiffTypeChanged DiffKind = "type-changed" // The value has a different JSON type (e.g. string became number).
+)
+
+// Representations of arrays in flattened JSON.
+const (
+	FlattenArraysIndexed   FlattenArrays = iota // Elements are keyed by index, like members: "RECORDS.0.DATA_SOURCE".
+	FlattenArraysBracketed                      // Elements are keyed by bracketed index: "RECORDS[0].DATA_SOURCE".
+	FlattenArraysKeep                           // Arrays are not flattened, but kept as values: "RECORDS": [...].
+)
+
+// Naming conventions for JSON keys.
+const (
+	KeyCaseCamel      KeyCase = iota // e.g. "entityId".
+	KeyCaseKebab                     // e.g. "entity-id".
+	KeyCaseLowerSnake                // e.g. "entity_id".
+	KeyCasePascal                    // e.g. "EntityId".
+	KeyCaseUpperSnake                // e.g. "ENTITY_ID", as used by Senzing.
+)
+
+// Actions for detected PII.
+const (
+	PIIReplace      PIIAction = iota // Replace the detected text with PIIRule.Replacement.
+	PIINull                          // Replace the entire JSON string containing the detected text with null.
+	PIIPseudonymize                  // Replace the detected text with a keyed pseudonym. See Pseudonymize.
+)
+
+// Kinds of PII.
+const (
+	PIIEmail          PIIKind = "EMAIL"          // Email addresses.
+	PIILicenseBase64  PIIKind = "LICENSE_BASE64" // Long base64 blobs, like Senzing license strings.
+	PIIPaymentCard    PIIKind = "PAYMENT_CARD"   // Payment card numbers (PAN) that pass the Luhn check.
+	PIIPhone          PIIKind = "PHONE"          // Telephone numbers.
+	PIISocialSecurity PIIKind = "SSN"            // US Social Security Numbers.
+)
+
+// Kinds of validation issues.
+const (
+	ValidationDuplicateKey  ValidationKind = "duplicate-key"  // An object has the key more than once.
+	ValidationInvalidUTF8   ValidationKind = "invalid-utf8"   // A string is not valid UTF-8.
+	ValidationLoneSurrogate ValidationKind = "lone-surrogate" // A \u escape is half of a UTF-16 surrogate pair.
+	ValidationSyntax        ValidationKind = "syntax"         // The text is not JSON.
+	ValidationTooDeep       ValidationKind = "too-deep"       // Arrays and objects are nested too deeply.
+	ValidationTooLarge      ValidationKind = "too-large"      // The text is too long.
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var errForPackage = errors.New("jsonutil")
@@ -0,0 +1,582 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// JSON Patch operations (RFC 6902).
+const (
+	patchAdd     = "add"
+	patchCopy    = "copy"
+	patchMove    = "move"
+	patchRemove  = "remove"
+	patchReplace = "replace"
+	patchTest    = "test"
+)
+
+// The JSON Pointer array index that means "after the last element" (RFC 6901).
+const pointerEndOfArray = "-"
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The ApplyMergePatch function applies a JSON Merge Patch (RFC 7396) to a JSON document.
+Members of a patch object replace members of the document, members with a null value
+are removed, and nested objects are merged recursively. A patch that is not an object
+replaces the whole document.
+
+For example, applying
+
+	{"NAME": "Joseph", "SSN": null}
+
+to
+
+	{"NAME": "Joe", "SSN": "111-22-3333", "AGE": 35}
+
+gives
+
+	{"AGE":35,"NAME":"Joseph"}
+
+Input
+  - jsonText: The JSON document to be patched.
+  - mergePatchText: The merge patch.
+
+Output
+  - The JSON text representing the patched JSON.
+  - An error if either text is not JSON.
+*/
+func ApplyMergePatch(jsonText string, mergePatchText string) (string, error) {
+	document, err := unmarshalValue(jsonText)
+	if err != nil {
+		return jsonText, wraperror.Errorf(err, "Unmarshal jsonText")
+	}
+
+	mergePatch, err := unmarshalValue(mergePatchText)
+	if err != nil {
+		return jsonText, wraperror.Errorf(err, "Unmarshal mergePatchText")
+	}
+
+	patchedJSON, err := json.Marshal(applyMergePatch(document, mergePatch))
+
+	return string(patchedJSON), wraperror.Error(err)
+}
+
+/*
+The ApplyPatch function applies a JSON Patch (RFC 6902) to a JSON document.
+The patch is an array of "add", "remove", "replace", "move", "copy", and "test" operations,
+whose locations are JSON Pointers (RFC 6901). For example:
+
+	[{"op": "replace", "path": "/NAME", "value": "Joseph"}, {"op": "remove", "path": "/RECORDS/0"}]
+
+Operations are applied in order.
+If any operation fails (including a "test" operation whose value does not match),
+no change is made and an error is returned.
+
+Input
+  - jsonText: The JSON document to be patched.
+  - patchText: The JSON Patch.
+
+Output
+  - The JSON text representing the patched JSON.
+  - An error if either text is not JSON, the patch is malformed, or an operation fails.
+*/
+func ApplyPatch(jsonText string, patchText string) (string, error) {
+	document, err := unmarshalValue(jsonText)
+	if err != nil {
+		return jsonText, wraperror.Errorf(err, "Unmarshal jsonText")
+	}
+
+	patch, err := unmarshalValue(patchText)
+	if err != nil {
+		return jsonText, wraperror.Errorf(err, "Unmarshal patchText")
+	}
+
+	operations, isArray := patch.([]any)
+	if !isArray {
+		return jsonText, wraperror.Errorf(errForPackage, "patch is not an array")
+	}
+
+	for index, operation := range operations {
+		document, err = applyPatchOperation(document, operation)
+		if err != nil {
+			return jsonText, wraperror.Errorf(err, "patch operation %d", index)
+		}
+	}
+
+	patchedJSON, err := json.Marshal(document)
+
+	return string(patchedJSON), wraperror.Error(err)
+}
+
+/*
+The CreateMergePatch function returns a JSON Merge Patch (RFC 7396) that changes one JSON document into another.
+Merge patches cannot set a member to null, as null means "remove"; use [CreatePatch] for such changes.
+
+Input
+  - jsonText1: The original JSON document.
+  - jsonText2: The changed JSON document.
+
+Output
+  - The merge patch, for use with [ApplyMergePatch].
+  - An error if either text is not JSON.
+*/
+func CreateMergePatch(jsonText1 string, jsonText2 string) (string, error) {
+	document1, err := unmarshalValue(jsonText1)
+	if err != nil {
+		return "", wraperror.Errorf(err, "Unmarshal jsonText1")
+	}
+
+	document2, err := unmarshalValue(jsonText2)
+	if err != nil {
+		return "", wraperror.Errorf(err, "Unmarshal jsonText2")
+	}
+
+	mergePatchJSON, err := json.Marshal(createMergePatch(document1, document2))
+
+	return string(mergePatchJSON), wraperror.Error(err)
+}
+
+/*
+The CreatePatch function returns a JSON Patch (RFC 6902) that changes one JSON document into another.
+Like [Diff], it ignores object key order and number formatting.
+The patch uses "add", "remove", and "replace" operations; array elements are removed from the end first,
+so the patch applies cleanly in order.
+
+Input
+  - jsonText1: The original JSON document.
+  - jsonText2: The changed JSON document.
+
+Output
+  - The patch, for use with [ApplyPatch]. "[]" if the documents are equivalent.
+  - An error if either text is not JSON.
+*/
+func CreatePatch(jsonText1 string, jsonText2 string) (string, error) {
+	document1, err := unmarshalValue(jsonText1)
+	if err != nil {
+		return "", wraperror.Errorf(err, "Unmarshal jsonText1")
+	}
+
+	document2, err := unmarshalValue(jsonText2)
+	if err != nil {
+		return "", wraperror.Errorf(err, "Unmarshal jsonText2")
+	}
+
+	patchJSON, err := json.Marshal(createPatch("", document1, document2, []map[string]any{}))
+
+	return string(patchJSON), wraperror.Error(err)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func addAtPointer(document any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	return modifyAtPointer(document, tokens, func(container any, token string) (any, error) {
+		switch typedContainer := container.(type) {
+		case map[string]any:
+			typedContainer[token] = value
+
+			return typedContainer, nil
+		case []any:
+			index, err := pointerIndex(token, len(typedContainer), true)
+			if err != nil {
+				return container, err
+			}
+
+			return slices.Insert(typedContainer, index, value), nil
+		default:
+			return container, wraperror.Errorf(errForPackage, "cannot add to a %s", jsonType(container))
+		}
+	})
+}
+
+func applyMergePatch(document any, mergePatch any) any {
+	patchObject, isObject := mergePatch.(map[string]any)
+	if !isObject {
+		return mergePatch
+	}
+
+	documentObject, isObject := document.(map[string]any)
+	if !isObject {
+		documentObject = map[string]any{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(documentObject, key)
+		} else {
+			documentObject[key] = applyMergePatch(documentObject[key], value)
+		}
+	}
+
+	return documentObject
+}
+
+func applyPatchOperation(document any, operation any) (any, error) {
+	fields, isObject := operation.(map[string]any)
+	if !isObject {
+		return document, wraperror.Errorf(errForPackage, "operation is not an object")
+	}
+
+	opName, _ := fields["op"].(string)
+
+	path, err := patchPointer(fields, "path")
+	if err != nil {
+		return document, err
+	}
+
+	value, hasValue := fields["value"]
+	if !hasValue && (opName == patchAdd || opName == patchReplace || opName == patchTest) {
+		return document, wraperror.Errorf(errForPackage, "%s operation has no value member", opName)
+	}
+
+	switch opName {
+	case patchAdd:
+		return addAtPointer(document, path, value)
+	case patchRemove:
+		document, _, err = removeAtPointer(document, path)
+
+		return document, err
+	case patchReplace:
+		document, _, err = removeAtPointer(document, path)
+		if err != nil {
+			return document, err
+		}
+
+		return addAtPointer(document, path, value)
+	case patchMove, patchCopy:
+		return applyMoveOrCopy(document, opName, fields, path)
+	case patchTest:
+		actual, testErr := valueAtPointer(document, path)
+		if testErr != nil {
+			return document, testErr
+		}
+
+		if !valuesEqual(actual, value) {
+			return document, wraperror.Errorf(errForPackage, "test failed: %s is %s", fields["path"], renderValue(actual))
+		}
+
+		return document, nil
+	default:
+		return document, wraperror.Errorf(errForPackage, "unknown op %s", renderValue(fields["op"]))
+	}
+}
+
+func applyMoveOrCopy(document any, opName string, fields map[string]any, path []string) (any, error) {
+	from, err := patchPointer(fields, "from")
+	if err != nil {
+		return document, err
+	}
+
+	if opName == patchCopy {
+		value, copyErr := valueAtPointer(document, from)
+		if copyErr != nil {
+			return document, copyErr
+		}
+
+		return addAtPointer(document, path, copyValue(value))
+	}
+
+	if len(path) > len(from) && slices.Equal(path[:len(from)], from) {
+		return document, wraperror.Errorf(errForPackage, "cannot move %s into itself", fields["from"])
+	}
+
+	document, value, err := removeAtPointer(document, from)
+	if err != nil {
+		return document, err
+	}
+
+	return addAtPointer(document, path, value)
+}
+
+func copyValue(jsonValue any) any {
+	switch typedJSON := jsonValue.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(typedJSON))
+		for key, value := range typedJSON {
+			result[key] = copyValue(value)
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(typedJSON))
+		for index, value := range typedJSON {
+			result[index] = copyValue(value)
+		}
+
+		return result
+	default:
+		return jsonValue
+	}
+}
+
+func createMergePatch(document1 any, document2 any) any {
+	object1, isObject1 := document1.(map[string]any)
+	object2, isObject2 := document2.(map[string]any)
+
+	if !isObject1 || !isObject2 {
+		return document2
+	}
+
+	result := map[string]any{}
+
+	for key := range object1 {
+		if _, isInObject2 := object2[key]; !isInObject2 {
+			result[key] = nil
+		}
+	}
+
+	for key, value2 := range object2 {
+		value1, isInObject1 := object1[key]
+
+		switch {
+		case !isInObject1:
+			result[key] = value2
+		case !valuesEqual(value1, value2):
+			result[key] = createMergePatch(value1, value2)
+		}
+	}
+
+	return result
+}
+
+func createPatch(pointer string, value1 any, value2 any, result []map[string]any) []map[string]any {
+	object1, isObject1 := value1.(map[string]any)
+	object2, isObject2 := value2.(map[string]any)
+
+	if isObject1 && isObject2 {
+		keys := slices.Sorted(maps.Keys(object1))
+		for _, key := range keys {
+			if _, isInObject2 := object2[key]; !isInObject2 {
+				result = append(result, map[string]any{"op": patchRemove, "path": appendPointer(pointer, key)})
+			}
+		}
+
+		keys = slices.Sorted(maps.Keys(object2))
+		for _, key := range keys {
+			if _, isInObject1 := object1[key]; isInObject1 {
+				result = createPatch(appendPointer(pointer, key), object1[key], object2[key], result)
+			} else {
+				result = append(result, map[string]any{"op": patchAdd, "path": appendPointer(pointer, key), "value": object2[key]})
+			}
+		}
+
+		return result
+	}
+
+	array1, isArray1 := value1.([]any)
+	array2, isArray2 := value2.([]any)
+
+	if isArray1 && isArray2 {
+		for index := range min(len(array1), len(array2)) {
+			result = createPatch(appendPointer(pointer, strconv.Itoa(index)), array1[index], array2[index], result)
+		}
+
+		for index := len(array1) - 1; index >= len(array2); index-- {
+			result = append(result, map[string]any{"op": patchRemove, "path": appendPointer(pointer, strconv.Itoa(index))})
+		}
+
+		for index := len(array1); index < len(array2); index++ {
+			result = append(result, map[string]any{
+				"op":    patchAdd,
+				"path":  appendPointer(pointer, strconv.Itoa(index)),
+				"value": array2[index],
+			})
+		}
+
+		return result
+	}
+
+	if !valuesEqual(value1, value2) {
+		result = append(result, map[string]any{"op": patchReplace, "path": pointer, "value": value2})
+	}
+
+	return result
+}
+
+func appendPointer(pointer string, token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	token = strings.ReplaceAll(token, "/", "~1")
+
+	return pointer + "/" + token
+}
+
+/*
+Apply change to the object or array holding the value at the pointer, and return the changed document.
+change is called with the container and the last token of the pointer, and returns the changed container,
+which replaces the original (an array may grow or shrink).
+*/
+func modifyAtPointer(
+	document any,
+	tokens []string,
+	change func(container any, token string) (any, error),
+) (any, error) {
+	if len(tokens) == 1 {
+		return change(document, tokens[0])
+	}
+
+	switch typedDocument := document.(type) {
+	case map[string]any:
+		child, isInObject := typedDocument[tokens[0]]
+		if !isInObject {
+			return document, wraperror.Errorf(errForPackage, "no member %s", tokens[0])
+		}
+
+		child, err := modifyAtPointer(child, tokens[1:], change)
+		typedDocument[tokens[0]] = child
+
+		return typedDocument, err
+	case []any:
+		index, err := pointerIndex(tokens[0], len(typedDocument), false)
+		if err != nil {
+			return document, err
+		}
+
+		child, err := modifyAtPointer(typedDocument[index], tokens[1:], change)
+		typedDocument[index] = child
+
+		return typedDocument, err
+	default:
+		return document, wraperror.Errorf(errForPackage, "cannot find %s in a %s", tokens[0], jsonType(document))
+	}
+}
+
+func parsePointer(pointer string) ([]string, error) {
+	if len(pointer) == 0 {
+		return []string{}, nil
+	}
+
+	if pointer[0] != '/' {
+		return nil, wraperror.Errorf(errForPackage, "JSON Pointer must start with /: %s", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for index, token := range tokens {
+		token = strings.ReplaceAll(token, "~1", "/")
+		tokens[index] = strings.ReplaceAll(token, "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func patchPointer(fields map[string]any, name string) ([]string, error) {
+	pointer, isString := fields[name].(string)
+	if !isString {
+		return nil, wraperror.Errorf(errForPackage, "operation has no %s string", name)
+	}
+
+	return parsePointer(pointer)
+}
+
+/*
+Parse an array index token: a non-negative integer without leading zeros, less than length.
+If allowEnd (for "add"), the index may also be length, which "-" stands for.
+*/
+func pointerIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd {
+		if token == pointerEndOfArray {
+			return length, nil
+		}
+
+		length++
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil || strings.Trim(token, "0123456789") != "" || (len(token) > 1 && token[0] == '0') ||
+		index >= length {
+		return 0, wraperror.Errorf(errForPackage, "invalid array index %s", token)
+	}
+
+	return index, nil
+}
+
+func removeAtPointer(document any, tokens []string) (any, any, error) {
+	var removed any
+
+	if len(tokens) == 0 {
+		return nil, document, nil
+	}
+
+	result, err := modifyAtPointer(document, tokens, func(container any, token string) (any, error) {
+		switch typedContainer := container.(type) {
+		case map[string]any:
+			value, isInObject := typedContainer[token]
+			if !isInObject {
+				return container, wraperror.Errorf(errForPackage, "no member %s", token)
+			}
+
+			removed = value
+			delete(typedContainer, token)
+
+			return typedContainer, nil
+		case []any:
+			index, err := pointerIndex(token, len(typedContainer), false)
+			if err != nil {
+				return container, err
+			}
+
+			removed = typedContainer[index]
+
+			return slices.Delete(typedContainer, index, index+1), nil
+		default:
+			return container, wraperror.Errorf(errForPackage, "cannot find %s in a %s", token, jsonType(container))
+		}
+	})
+
+	return result, removed, err
+}
+
+func unmarshalValue(jsonText string) (any, error) {
+	var result any
+
+	parsedJSON, err := unmarshal(jsonText)
+	if parsedJSON != nil {
+		result = *parsedJSON
+	}
+
+	return result, err
+}
+
+func valueAtPointer(document any, tokens []string) (any, error) {
+	result := document
+
+	for _, token := range tokens {
+		switch typedResult := result.(type) {
+		case map[string]any:
+			value, isInObject := typedResult[token]
+			if !isInObject {
+				return nil, wraperror.Errorf(errForPackage, "no member %s", token)
+			}
+
+			result = value
+		case []any:
+			index, err := pointerIndex(token, len(typedResult), false)
+			if err != nil {
+				return nil, err
+			}
+
+			result = typedResult[index]
+		default:
+			return nil, wraperror.Errorf(errForPackage, "cannot find %s in a %s", token, jsonType(result))
+		}
+	}
+
+	return result, nil
+}
+
+// Values are equal if Diff would find no differences: object key order and number formatting do not matter.
+func valuesEqual(value1 any, value2 any) bool {
+	return len(diffValues("", value1, value2, DiffOptions{IgnoreArrayOrder: false, IgnoreKeys: nil}, nil)) == 0
+}
@@ -0,0 +1,372 @@
+package jsonutil_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Many test cases are from the examples in RFC 6902, Appendix A, and RFC 7396, Appendix A.
+
+var testCasesForApplyPatch = []struct {
+	name      string
+	jsonText  string
+	patchText string
+	expected  string
+}{
+	{
+		name:      "add-member",
+		jsonText:  `{"foo": "bar"}`,
+		patchText: `[{"op": "add", "path": "/baz", "value": "qux"}]`,
+		expected:  `{"baz":"qux","foo":"bar"}`,
+	},
+	{
+		name:      "add-array-element",
+		jsonText:  `{"foo": ["bar", "baz"]}`,
+		patchText: `[{"op": "add", "path": "/foo/1", "value": "qux"}]`,
+		expected:  `{"foo":["bar","qux","baz"]}`,
+	},
+	{
+		name:      "add-end-of-array",
+		jsonText:  `{"foo": ["bar"]}`,
+		patchText: `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`,
+		expected:  `{"foo":["bar",["abc","def"]]}`,
+	},
+	{
+		name:      "add-null",
+		jsonText:  `{"foo": "bar"}`,
+		patchText: `[{"op": "add", "path": "/baz", "value": null}]`,
+		expected:  `{"baz":null,"foo":"bar"}`,
+	},
+	{
+		name:      "remove-member",
+		jsonText:  `{"baz": "qux", "foo": "bar"}`,
+		patchText: `[{"op": "remove", "path": "/baz"}]`,
+		expected:  `{"foo":"bar"}`,
+	},
+	{
+		name:      "remove-array-element",
+		jsonText:  `{"foo": ["bar", "qux", "baz"]}`,
+		patchText: `[{"op": "remove", "path": "/foo/1"}]`,
+		expected:  `{"foo":["bar","baz"]}`,
+	},
+	{
+		name:      "replace",
+		jsonText:  `{"baz": "qux", "foo": "bar"}`,
+		patchText: `[{"op": "replace", "path": "/baz", "value": "boo"}]`,
+		expected:  `{"baz":"boo","foo":"bar"}`,
+	},
+	{
+		name:      "replace-root",
+		jsonText:  `{"foo": "bar"}`,
+		patchText: `[{"op": "replace", "path": "", "value": [1]}]`,
+		expected:  `[1]`,
+	},
+	{
+		name:      "move-member",
+		jsonText:  `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+		patchText: `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+		expected:  `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+	},
+	{
+		name:      "move-array-element",
+		jsonText:  `{"foo": ["all", "grass", "cows", "eat"]}`,
+		patchText: `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`,
+		expected:  `{"foo":["all","cows","eat","grass"]}`,
+	},
+	{
+		name:      "copy",
+		jsonText:  `{"foo": {"bar": [1]}}`,
+		patchText: `[{"op": "copy", "from": "/foo", "path": "/baz"}, {"op": "add", "path": "/baz/bar/-", "value": 2}]`,
+		expected:  `{"baz":{"bar":[1,2]},"foo":{"bar":[1]}}`,
+	},
+	{
+		name:      "test",
+		jsonText:  `{"baz": "qux", "foo": ["a", 2, "c"], "n": 1.50}`,
+		patchText: `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}, {"op": "test", "path": "/n", "value": 1.5}]`,
+		expected:  `{"baz":"qux","foo":["a",2,"c"],"n":1.50}`,
+	},
+	{
+		name:      "escaped-pointer",
+		jsonText:  `{"/": 9, "~1": 10}`,
+		patchText: `[{"op": "replace", "path": "/~01", "value": 11}, {"op": "remove", "path": "/~1"}]`,
+		expected:  `{"~1":11}`,
+	},
+	{
+		name:      "large-numbers",
+		jsonText:  `{"ENTITY_ID": 9007199254740993}`,
+		patchText: `[{"op": "add", "path": "/RELATED_ID", "value": 9007199254740995}]`,
+		expected:  `{"ENTITY_ID":9007199254740993,"RELATED_ID":9007199254740995}`,
+	},
+}
+
+var testCasesForApplyPatchErrors = []struct {
+	name      string
+	jsonText  string
+	patchText string
+}{
+	{name: "bad-json", jsonText: badJSON, patchText: `[]`},
+	{name: "bad-patch", jsonText: `{}`, patchText: badJSON},
+	{name: "not-an-array", jsonText: `{}`, patchText: `{"op": "remove", "path": "/a"}`},
+	{name: "not-an-object", jsonText: `{}`, patchText: `["remove"]`},
+	{name: "unknown-op", jsonText: `{}`, patchText: `[{"op": "delete", "path": "/a"}]`},
+	{name: "missing-path", jsonText: `{}`, patchText: `[{"op": "remove"}]`},
+	{name: "missing-value", jsonText: `{}`, patchText: `[{"op": "add", "path": "/a"}]`},
+	{name: "missing-from", jsonText: `{"a": 1}`, patchText: `[{"op": "move", "path": "/b"}]`},
+	{name: "bad-pointer", jsonText: `{"a": 1}`, patchText: `[{"op": "remove", "path": "a"}]`},
+	{name: "remove-missing", jsonText: `{"a": 1}`, patchText: `[{"op": "remove", "path": "/b"}]`},
+	{name: "replace-missing", jsonText: `{"a": 1}`, patchText: `[{"op": "replace", "path": "/b", "value": 2}]`},
+	{name: "add-missing-parent", jsonText: `{"a": 1}`, patchText: `[{"op": "add", "path": "/b/c", "value": 2}]`},
+	{name: "add-to-scalar", jsonText: `{"a": 1}`, patchText: `[{"op": "add", "path": "/a/b", "value": 2}]`},
+	{name: "index-out-of-range", jsonText: `[1, 2]`, patchText: `[{"op": "add", "path": "/3", "value": 3}]`},
+	{name: "index-leading-zero", jsonText: `[1, 2]`, patchText: `[{"op": "remove", "path": "/01"}]`},
+	{name: "index-negative", jsonText: `[1, 2]`, patchText: `[{"op": "remove", "path": "/-1"}]`},
+	{name: "index-end-of-array", jsonText: `[1, 2]`, patchText: `[{"op": "remove", "path": "/-"}]`},
+	{name: "move-into-itself", jsonText: `{"a": {"b": 1}}`, patchText: `[{"op": "move", "from": "/a", "path": "/a/c"}]`},
+	{name: "test-failed", jsonText: `{"a": "1"}`, patchText: `[{"op": "test", "path": "/a", "value": 1}]`},
+	{
+		name:      "later-operation-failed",
+		jsonText:  `{"a": 1}`,
+		patchText: `[{"op": "remove", "path": "/a"}, {"op": "test", "path": "/a", "value": 1}]`,
+	},
+}
+
+var testCasesForApplyMergePatch = []struct {
+	name           string
+	jsonText       string
+	mergePatchText string
+	expected       string
+}{
+	{name: "replace", jsonText: `{"a": "b"}`, mergePatchText: `{"a": "c"}`, expected: `{"a":"c"}`},
+	{name: "add", jsonText: `{"a": "b"}`, mergePatchText: `{"b": "c"}`, expected: `{"a":"b","b":"c"}`},
+	{name: "remove", jsonText: `{"a": "b", "b": "c"}`, mergePatchText: `{"a": null}`, expected: `{"b":"c"}`},
+	{name: "replace-array", jsonText: `{"a": ["b"]}`, mergePatchText: `{"a": "c"}`, expected: `{"a":"c"}`},
+	{name: "replace-with-array", jsonText: `{"a": "c"}`, mergePatchText: `{"a": ["b"]}`, expected: `{"a":["b"]}`},
+	{
+		name:           "nested",
+		jsonText:       `{"a": {"b": "c"}}`,
+		mergePatchText: `{"a": {"b": "d", "c": null}}`,
+		expected:       `{"a":{"b":"d"}}`,
+	},
+	{name: "array-of-objects", jsonText: `{"a": [{"b": "c"}]}`, mergePatchText: `{"a": [1]}`, expected: `{"a":[1]}`},
+	{name: "array-root", jsonText: `["a", "b"]`, mergePatchText: `["c", "d"]`, expected: `["c","d"]`},
+	{name: "object-over-array", jsonText: `["a"]`, mergePatchText: `{"a": "b"}`, expected: `{"a":"b"}`},
+	{name: "null-patch", jsonText: `{"a": "foo"}`, mergePatchText: `null`, expected: `null`},
+	{name: "string-patch", jsonText: `{"a": "foo"}`, mergePatchText: `"bar"`, expected: `"bar"`},
+	{name: "nested-null", jsonText: `{"e": null}`, mergePatchText: `{"a": 1}`, expected: `{"a":1,"e":null}`},
+	{name: "new-nested", jsonText: `[1, 2]`, mergePatchText: `{"a": "b", "c": null}`, expected: `{"a":"b"}`},
+	{
+		name:           "new-nested-object",
+		jsonText:       `{}`,
+		mergePatchText: `{"a": {"bb": {"ccc": null}}}`,
+		expected:       `{"a":{"bb":{}}}`,
+	},
+}
+
+var testCasesForCreatePatch = []struct {
+	name      string
+	jsonText1 string
+	jsonText2 string
+	expected  string
+}{
+	{
+		name:      "equal",
+		jsonText1: `{"a": 1, "b": [1.0, {"c": true}]}`,
+		jsonText2: `{"b": [1, {"c": true}], "a": 1.00}`,
+		expected:  `[]`,
+	},
+	{
+		name:      "members",
+		jsonText1: `{"NAME": "Joe", "AGE": 35, "SSN": "111-22-3333"}`,
+		jsonText2: `{"NAME": "Joe", "AGE": 36, "EMAIL": "joe@example.com"}`,
+		expected:  `[{"op":"remove","path":"/SSN"},{"op":"replace","path":"/AGE","value":36},{"op":"add","path":"/EMAIL","value":"joe@example.com"}]`,
+	},
+	{
+		name:      "shorter-array",
+		jsonText1: `{"a/b": [1, 2, 3, 4]}`,
+		jsonText2: `{"a/b": [0, 2]}`,
+		expected:  `[{"op":"replace","path":"/a~1b/0","value":0},{"op":"remove","path":"/a~1b/3"},{"op":"remove","path":"/a~1b/2"}]`,
+	},
+	{
+		name:      "longer-array",
+		jsonText1: `[1]`,
+		jsonText2: `[1, [2], null]`,
+		expected:  `[{"op":"add","path":"/1","value":[2]},{"op":"add","path":"/2","value":null}]`,
+	},
+	{
+		name:      "type-changed",
+		jsonText1: `{"a": {"b": 1}}`,
+		jsonText2: `{"a": [1]}`,
+		expected:  `[{"op":"replace","path":"/a","value":[1]}]`,
+	},
+	{
+		name:      "root",
+		jsonText1: `"a"`,
+		jsonText2: `null`,
+		expected:  `[{"op":"replace","path":"","value":null}]`,
+	},
+}
+
+var testCasesForCreateMergePatch = []struct {
+	name      string
+	jsonText1 string
+	jsonText2 string
+	expected  string
+}{
+	{
+		name:      "equal",
+		jsonText1: `{"a": 1, "b": [1.0]}`,
+		jsonText2: `{"b": [1], "a": 1.00}`,
+		expected:  `{}`,
+	},
+	{
+		name:      "members",
+		jsonText1: `{"NAME": "Joe", "AGE": 35, "SSN": "111-22-3333", "ADDRESS": {"CITY": "Las Vegas", "STATE": "NV"}}`,
+		jsonText2: `{"NAME": "Joe", "AGE": 36, "EMAIL": "joe@example.com", "ADDRESS": {"CITY": "Reno", "STATE": "NV"}}`,
+		expected:  `{"ADDRESS":{"CITY":"Reno"},"AGE":36,"EMAIL":"joe@example.com","SSN":null}`,
+	},
+	{
+		name:      "array",
+		jsonText1: `{"a": [1, 2]}`,
+		jsonText2: `{"a": [1]}`,
+		expected:  `{"a":[1]}`,
+	},
+	{
+		name:      "not-objects",
+		jsonText1: `[1]`,
+		jsonText2: `"a"`,
+		expected:  `"a"`,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestApplyMergePatch(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForApplyMergePatch {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.ApplyMergePatch(testCase.jsonText, testCase.mergePatchText)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestApplyMergePatch_badJSON(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.ApplyMergePatch(badJSON, `{}`)
+	require.Error(test, err)
+	assert.Equal(test, badJSON, actual)
+	assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+
+	actual, err = jsonutil.ApplyMergePatch(`{}`, badJSON)
+	require.Error(test, err)
+	assert.Equal(test, `{}`, actual)
+	assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+}
+
+func TestApplyPatch(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForApplyPatch {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.ApplyPatch(testCase.jsonText, testCase.patchText)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestApplyPatch_errors(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForApplyPatchErrors {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.ApplyPatch(testCase.jsonText, testCase.patchText)
+			require.Error(test, err)
+			assert.Equal(test, testCase.jsonText, actual)
+			assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+		})
+	}
+}
+
+func TestCreateMergePatch(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForCreateMergePatch {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.CreateMergePatch(testCase.jsonText1, testCase.jsonText2)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+
+			patched, err := jsonutil.ApplyMergePatch(testCase.jsonText1, actual)
+			require.NoError(test, err)
+			assertEquivalentJSON(test, testCase.jsonText2, patched)
+		})
+	}
+}
+
+func TestCreateMergePatch_badJSON(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.CreateMergePatch(badJSON, `{}`)
+	require.Error(test, err)
+
+	_, err = jsonutil.CreateMergePatch(`{}`, badJSON)
+	require.Error(test, err)
+}
+
+func TestCreatePatch(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForCreatePatch {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.CreatePatch(testCase.jsonText1, testCase.jsonText2)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+
+			patched, err := jsonutil.ApplyPatch(testCase.jsonText1, actual)
+			require.NoError(test, err)
+			assertEquivalentJSON(test, testCase.jsonText2, patched)
+		})
+	}
+}
+
+func TestCreatePatch_badJSON(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.CreatePatch(badJSON, `{}`)
+	require.Error(test, err)
+
+	_, err = jsonutil.CreatePatch(`{}`, badJSON)
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func assertEquivalentJSON(test *testing.T, expected string, actual string) {
+	test.Helper()
+
+	differences, err := jsonutil.Diff(expected, actual, jsonutil.DiffOptions{IgnoreArrayOrder: false, IgnoreKeys: nil})
+	require.NoError(test, err)
+	assert.Empty(test, differences, jsonutil.RenderDiff(differences))
+}
@@ -0,0 +1,363 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+const (
+	hashLength      = 16 // Number of hexadecimal digits in pseudonym tokens.
+	maxPANDigits    = 19
+	maxPhoneDigits  = 15
+	minPANDigits    = 13
+	minPhoneDigits  = 7
+	luhnDoubleLimit = 9
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A piiDetector finds candidates with a regular expression, then optionally checks each candidate.
+type piiDetector struct {
+	kind    PIIKind
+	pattern *regexp.Regexp
+	isValid func(candidate string) bool
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// PII detectors, in priority order: when matches overlap, the earliest and then highest priority match is kept.
+var piiDetectors = []piiDetector{
+	{
+		kind:    PIILicenseBase64,
+		pattern: regexp.MustCompile(`[A-Za-z0-9+/]{100,}={0,2}`),
+		isValid: isMixedBase64,
+	},
+	{
+		kind:    PIIEmail,
+		pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+		isValid: nil,
+	},
+	{
+		kind: PIIPaymentCard,
+		pattern: regexp.MustCompile(
+			`\b(?:\d{13,19}` + // Ungrouped.
+				`|\d{4}(?:[ -]\d{4}){2,3}(?:[ -]\d{1,4})?` + // Groups of 4.
+				`|\d{4}[ -]\d{6}[ -]\d{4,5})\b`, // Groups of 4, 6, and 5 (e.g. American Express).
+		),
+		isValid: isLuhnValid,
+	},
+	{
+		kind:    PIISocialSecurity,
+		pattern: regexp.MustCompile(`\b\d{3}[- ]\d{2}[- ]\d{4}\b`),
+		isValid: isSocialSecurityNumber,
+	},
+	{
+		kind: PIIPhone,
+		pattern: regexp.MustCompile(
+			`(?:\+?1[ .-]?)?(?:\(\d{3}\)[ .-]?|\b\d{3}[ .-])\d{3}[ .-]\d{4}\b` + // North American numbers.
+				`|\+\d{1,3}(?:[ .-]\d{2,8}){1,4}\b` + // International numbers.
+				`|\b\d{3}-\d{4}\b`, // Local numbers.
+		),
+		isValid: isPhoneNumber,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The DefaultPIIRules function returns rules that replace every kind of PII with "[KIND]" (e.g. "[SSN]").
+The result may be modified and passed to [RedactPII].
+
+Output
+  - A rule for every kind of PII.
+*/
+func DefaultPIIRules() map[PIIKind]PIIRule {
+	result := make(map[PIIKind]PIIRule, len(piiDetectors))
+	for _, detector := range piiDetectors {
+		result[detector.kind] = PIIRule{
+			Action:           PIIReplace,
+			FormatPreserving: false,
+			Key:              nil,
+			Replacement:      "",
+		}
+	}
+
+	return result
+}
+
+/*
+The FindPII function finds personally identifiable information (PII) in text by its form,
+regardless of where the text came from.
+Detection is heuristic:
+
+  - SSN: "123-45-6789" or "123 45 6789", excluding numbers that are never issued (e.g. "000-12-3456").
+  - EMAIL: "name@example.com".
+  - PHONE: "702-919-1300", "(702) 919-1300", "+1 702 919 1300", "+39 0352 6553537", "919-1300".
+  - PAYMENT_CARD: 13 to 19 digits, optionally in groups separated by spaces or dashes, that pass the Luhn check.
+  - LICENSE_BASE64: Runs of 100 or more base64 characters, mixing upper case, lower case, and digits.
+
+Input
+  - text: The text to search.
+  - kinds: The kinds of PII to find. If none are given, all kinds are found.
+
+Output
+  - Non-overlapping matches, in order of their position in the text.
+*/
+func FindPII(text string, kinds ...PIIKind) []PIIMatch {
+	var candidates []PIIMatch
+
+	for _, detector := range piiDetectors {
+		if len(kinds) > 0 && !slices.Contains(kinds, detector.kind) {
+			continue
+		}
+
+		for _, location := range detector.pattern.FindAllStringIndex(text, -1) {
+			candidate := text[location[0]:location[1]]
+			if detector.isValid != nil && !detector.isValid(candidate) {
+				continue
+			}
+
+			candidates = append(candidates, PIIMatch{
+				End:   location[1],
+				Kind:  detector.kind,
+				Start: location[0],
+				Value: candidate,
+			})
+		}
+	}
+
+	// Order by position, then priority (the stable sort keeps detector order), then keep only matches
+	// that do not overlap an earlier one.
+
+	slices.SortStableFunc(candidates, func(match1 PIIMatch, match2 PIIMatch) int {
+		return match1.Start - match2.Start
+	})
+
+	result := make([]PIIMatch, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		if len(result) > 0 && candidate.Start < result[len(result)-1].End {
+			continue
+		}
+
+		result = append(result, candidate)
+	}
+
+	return result
+}
+
+/*
+The RedactPII function finds personally identifiable information (PII) in the string values of JSON,
+under any key and inside free text (see [FindPII]), and handles each kind of PII as its rule says.
+Kinds of PII without a rule are left alone.
+Keys, numbers, booleans, and null values are not changed.
+
+Numbers are not searched, as numeric IDs (e.g. an ENTITY_ID of 1000000000000008) often look like
+payment card numbers, and PII stored as a number (e.g. an SSN stored as 294669999) cannot be told
+from other numbers by its form. Redact such values by their key, with [RedactWithMap].
+
+For example, with [DefaultPIIRules],
+
+	{"NOTES": "Call 702-919-1300 or email bsmith@work.com"}
+
+becomes
+
+	{"NOTES":"Call [PHONE] or email [EMAIL]"}
+
+Input
+  - jsonText: The JSON text to be redacted.
+  - rules: How to handle each kind of PII. If nil, [DefaultPIIRules] is used.
+
+Output
+  - The JSON text representing the redacted JSON.
+  - An error if a rule for PIIPseudonymize has a key shorter than [MinPseudonymKeyLength] bytes (e.g. nil),
+    or if a failure occurred in unmarshalling the specified text.
+*/
+func RedactPII(jsonText string, rules map[PIIKind]PIIRule) (string, error) {
+	if rules == nil {
+		rules = DefaultPIIRules()
+	}
+
+	kinds := make([]PIIKind, 0, len(rules))
+	for kind := range rules {
+		kinds = append(kinds, kind)
+	}
+
+	slices.Sort(kinds)
+
+	for _, kind := range kinds {
+		rule := rules[kind]
+		if rule.Action == PIIPseudonymize && len(rule.Key) < MinPseudonymKeyLength {
+			return jsonText, wraperror.Errorf(errForPackage, "%s rule key has %d bytes, want at least %d",
+				kind, len(rule.Key), MinPseudonymKeyLength)
+		}
+	}
+
+	// unmarshall the text and let it allocate whatever object it wants to hold the result
+	parsedJSON, err := unmarshal(jsonText)
+	// check for an unmarshalling error
+	if err != nil {
+		return jsonText, wraperror.Errorf(err, "Unmarshal")
+	}
+
+	// check for a null literal which is unmarshalled as a nil pointer
+	if parsedJSON == nil {
+		return Null, nil
+	}
+
+	redactedValue := redactPIIValue(*parsedJSON, rules, kinds)
+	if redactedValue == nil {
+		return Null, nil
+	}
+
+	// marshall the redacted object back to text (bytes) and return the text and potential error
+	redactedJSON, err := json.Marshal(redactedValue)
+
+	return string(redactedJSON), wraperror.Error(err)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+The pseudonym of PII is based on its canonical form, so that, for example,
+"294-66-9999" and "294 66 9999" get the same pseudonym.
+*/
+func canonicalPII(kind PIIKind, value string) string {
+	switch kind {
+	case PIIEmail:
+		return strings.ToLower(value)
+	case PIIPaymentCard, PIIPhone, PIISocialSecurity:
+		return digitsOnly(value)
+	default:
+		return value
+	}
+}
+
+func countDigits(text string) int {
+	return len(digitsOnly(text))
+}
+
+func digitsOnly(text string) string {
+	return strings.Map(func(character rune) rune {
+		if character >= '0' && character <= '9' {
+			return character
+		}
+
+		return -1
+	}, text)
+}
+
+func isLuhnValid(candidate string) bool {
+	digits := digitsOnly(candidate)
+	if len(digits) < minPANDigits || len(digits) > maxPANDigits {
+		return false
+	}
+
+	sum := 0
+
+	for index := range len(digits) {
+		digit := int(digits[len(digits)-1-index] - '0')
+		if index%2 == 1 {
+			digit *= 2
+			if digit > luhnDoubleLimit {
+				digit -= luhnDoubleLimit
+			}
+		}
+
+		sum += digit
+	}
+
+	return sum%10 == 0
+}
+
+// Base64 blobs mix upper case, lower case, and digits; long words and numbers do not.
+func isMixedBase64(candidate string) bool {
+	return strings.ContainsFunc(candidate, unicode.IsUpper) &&
+		strings.ContainsFunc(candidate, unicode.IsLower) &&
+		strings.ContainsFunc(candidate, unicode.IsDigit)
+}
+
+func isPhoneNumber(candidate string) bool {
+	digitCount := countDigits(candidate)
+
+	return digitCount >= minPhoneDigits && digitCount <= maxPhoneDigits
+}
+
+// Area numbers 000, 666, and 900-999, group number 00, and serial number 0000 are never issued.
+func isSocialSecurityNumber(candidate string) bool {
+	digits := digitsOnly(candidate)
+	area, group, serial := digits[0:3], digits[3:5], digits[5:9]
+
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+func redactPII(text string, rules map[PIIKind]PIIRule, kinds []PIIKind) (string, bool) {
+	matches := FindPII(text, kinds...)
+	if len(matches) == 0 {
+		return text, true
+	}
+
+	var result strings.Builder
+
+	previousEnd := 0
+
+	for _, match := range matches {
+		rule := rules[match.Kind]
+
+		result.WriteString(text[previousEnd:match.Start])
+
+		switch rule.Action {
+		case PIINull:
+			return "", false
+		case PIIPseudonymize:
+			result.WriteString(pseudonymizePII(match.Kind, match.Value, rule))
+		default:
+			if len(rule.Replacement) > 0 {
+				result.WriteString(rule.Replacement)
+			} else {
+				result.WriteString("[" + string(match.Kind) + "]")
+			}
+		}
+
+		previousEnd = match.End
+	}
+
+	result.WriteString(text[previousEnd:])
+
+	return result.String(), true
+}
+
+func redactPIIValue(jsonValue any, rules map[PIIKind]PIIRule, kinds []PIIKind) any {
+	switch typedJSON := jsonValue.(type) {
+	case map[string]any:
+		for key, value := range typedJSON {
+			typedJSON[key] = redactPIIValue(value, rules, kinds)
+		}
+	case []any:
+		for index, value := range typedJSON {
+			typedJSON[index] = redactPIIValue(value, rules, kinds)
+		}
+	case string:
+		redacted, keep := redactPII(typedJSON, rules, kinds)
+		if !keep {
+			return nil
+		}
+
+		return redacted
+	}
+
+	return jsonValue
+}
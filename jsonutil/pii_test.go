@@ -0,0 +1,156 @@
+package jsonutil_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/senzing-garage/go-helpers/truthset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const licenseForPII = "AQAAADgCAAAAAAAAU2VuemluZyBJbnRlcm5hbCBMaWNlbnNlAGFuYWx5dGljc0BzZW56aW5nLmNvbQBTZW56aW5nIEluYwAx" +
+	"MjM0NTY3ODkwAHRlc3RpbmcgbGljZW5zZQ=="
+
+var testCasesForFindPII = []struct {
+	name     string
+	text     string
+	kinds    []jsonutil.PIIKind
+	expected []jsonutil.PIIKind
+}{
+	{name: "ssn", text: "SSN is 294-66-9999.", expected: []jsonutil.PIIKind{jsonutil.PIISocialSecurity}},
+	{name: "ssn-spaces", text: "294 66 9999", expected: []jsonutil.PIIKind{jsonutil.PIISocialSecurity}},
+	{name: "ssn-never-issued", text: "000-12-3456 666-12-3456 912-34-5678 123-00-4567 123-45-0000"},
+	{name: "email", text: "Maria Sentosa<msentosa@fmail.com>", expected: []jsonutil.PIIKind{jsonutil.PIIEmail}},
+	{name: "phone-nanp", text: "702-919-1300", expected: []jsonutil.PIIKind{jsonutil.PIIPhone}},
+	{name: "phone-parentheses", text: "(807) 422-9031", expected: []jsonutil.PIIKind{jsonutil.PIIPhone}},
+	{name: "phone-country-code", text: "+1 702 919 1300", expected: []jsonutil.PIIKind{jsonutil.PIIPhone}},
+	{name: "phone-international", text: "+39 0352 6553537", expected: []jsonutil.PIIKind{jsonutil.PIIPhone}},
+	{name: "phone-local", text: "call 221-2412 now", expected: []jsonutil.PIIKind{jsonutil.PIIPhone}},
+	{name: "pan", text: "card 4111 1111 1111 1111", expected: []jsonutil.PIIKind{jsonutil.PIIPaymentCard}},
+	{name: "pan-dashes", text: "5500-0000-0000-0004", expected: []jsonutil.PIIKind{jsonutil.PIIPaymentCard}},
+	{name: "pan-bad-luhn", text: "4111 1111 1111 1112"},
+	{name: "license", text: licenseForPII, expected: []jsonutil.PIIKind{jsonutil.PIILicenseBase64}},
+	{name: "long-word", text: "ABCDEFGHIJABCDEFGHIJABCDEFGHIJABCDEFGHIJABCDEFGHIJABCDEFGHIJABCDEFGHIJABCDEFGHIJABCDEFGHIJABCDEFGHIJ"},
+	{name: "not-pii", text: "RECORD_ID 1001, DATE 12/11/1978, AMOUNT 100, ZIP 89132"},
+	{
+		name:     "mixed",
+		text:     "SSN 294-66-9999, phone 702-919-1300, email bsmith@work.com",
+		expected: []jsonutil.PIIKind{jsonutil.PIISocialSecurity, jsonutil.PIIPhone, jsonutil.PIIEmail},
+	},
+	{
+		name:     "selected-kinds",
+		text:     "SSN 294-66-9999, phone 702-919-1300, email bsmith@work.com",
+		kinds:    []jsonutil.PIIKind{jsonutil.PIIEmail},
+		expected: []jsonutil.PIIKind{jsonutil.PIIEmail},
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestFindPII(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForFindPII {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual := []jsonutil.PIIKind{}
+			for _, match := range jsonutil.FindPII(testCase.text, testCase.kinds...) {
+				assert.Equal(test, match.Value, testCase.text[match.Start:match.End])
+
+				actual = append(actual, match.Kind)
+			}
+
+			assert.ElementsMatch(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestRedactPII(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"NOTES": "Call 702-919-1300 or email bsmith@work.com", "ID": 294669999, "294-66-9999": true}`
+	expected := `{"294-66-9999":true,"ID":294669999,"NOTES":"Call [PHONE] or email [EMAIL]"}`
+
+	actual, err := jsonutil.RedactPII(jsonText, nil)
+	require.NoError(test, err)
+	assert.Equal(test, expected, actual)
+}
+
+func TestRedactPII_badJSON(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.RedactPII(badJSON, nil)
+	require.Error(test, err)
+	assert.Equal(test, badJSON, actual)
+}
+
+// Numeric IDs that pass the Luhn check are not payment card numbers.
+func TestRedactPII_numbers(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"ENTITY_ID":1000000000000008,"RECORD_ID":4111111111111111,"SSN_NUMBER":294669999}`
+
+	actual, err := jsonutil.RedactPII(jsonText, nil)
+	require.NoError(test, err)
+	assert.Equal(test, jsonText, actual)
+
+	actual, err = jsonutil.RedactPII(jsonText, map[jsonutil.PIIKind]jsonutil.PIIRule{
+		jsonutil.PIIPaymentCard: {Action: jsonutil.PIINull, FormatPreserving: false, Key: nil, Replacement: ""},
+	})
+	require.NoError(test, err)
+	assert.Equal(test, jsonText, actual)
+}
+
+func TestRedactPII_nullAndReplacement(test *testing.T) {
+	test.Parallel()
+
+	rules := map[jsonutil.PIIKind]jsonutil.PIIRule{
+		jsonutil.PIIEmail:          {Action: jsonutil.PIINull, FormatPreserving: false, Key: nil, Replacement: ""},
+		jsonutil.PIISocialSecurity: {Action: jsonutil.PIIReplace, FormatPreserving: false, Key: nil, Replacement: "***-**-****"},
+	}
+
+	jsonText := `["294-66-9999", "Kusha123@hmail.com", {"a": "x bsmith@work.com"}, "702-919-1300", null, 5]`
+	expected := `["***-**-****",null,{"a":null},"702-919-1300",null,5]`
+
+	actual, err := jsonutil.RedactPII(jsonText, rules)
+	require.NoError(test, err)
+	assert.Equal(test, expected, actual)
+}
+
+func TestRedactPII_null(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.RedactPII(`null`, nil)
+	require.NoError(test, err)
+	assert.Equal(test, jsonutil.Null, actual)
+
+	actual, err = jsonutil.RedactPII(`"bsmith@work.com"`, map[jsonutil.PIIKind]jsonutil.PIIRule{
+		jsonutil.PIIEmail: {Action: jsonutil.PIINull, FormatPreserving: false, Key: nil, Replacement: ""},
+	})
+	require.NoError(test, err)
+	assert.Equal(test, jsonutil.Null, actual)
+}
+
+func TestRedactPII_truthset(test *testing.T) {
+	test.Parallel()
+
+	for recordID, customerRecord := range truthset.CustomerRecords {
+		test.Run(recordID, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.RedactPII(customerRecord.JSON, nil)
+			require.NoError(test, err)
+			assert.Empty(test, jsonutil.FindPII(actual))
+
+			redactedRecord, err := record.NewRecord(actual)
+			require.NoError(test, err)
+			assert.Equal(test, customerRecord.DataSource, redactedRecord.DataSource)
+			assert.Equal(test, customerRecord.ID, redactedRecord.ID)
+		})
+	}
+}
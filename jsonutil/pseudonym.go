@@ -0,0 +1,215 @@
+package jsonutil
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+const (
+	digitCount      = 10
+	letterCount     = 26
+	nonZeroDigits   = 9
+	pseudonymPrefix = "PSEUDO-"
+)
+
+// Domain separation, so a token and a format-preserving pseudonym of the same value are unrelated.
+const (
+	formatPreservingDomain = "format:"
+	tokenDomain            = "token:"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A keystream is an endless sequence of bytes derived from a key and a value with HMAC-SHA256.
+type keystream struct {
+	block   []byte
+	counter uint64
+	key     []byte
+	value   string
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Pseudonymize function returns a [RedactionFunc] that replaces values with pseudonyms computed
+by keyed hashing (HMAC-SHA256).
+The same value and key always give the same pseudonym, so redacted records can still be joined,
+but the value cannot be recovered (or guessed) without the key.
+
+Without format preservation, a value is replaced by a string like "PSEUDO-1f0e3d5c2b4a6978".
+
+With format preservation, each digit of a string is replaced by a digit, each upper case letter by an
+upper case letter, and each lower case letter by a lower case letter; other characters are kept.
+For example, the SSN "294-66-9999" might become "817-03-4412".
+Numbers stay numbers.
+Format-preserving pseudonyms of short values (e.g. a 4 digit number) can collide.
+
+JSON null values are not changed.
+
+Example:
+
+	pseudonymizer, err := jsonutil.Pseudonymize(key, true)
+	...
+	redactedJSON, err := jsonutil.RedactWithMap(jsonText, map[string]any{"SSN_NUMBER": pseudonymizer})
+
+Input
+  - key: The secret key, at least [MinPseudonymKeyLength] bytes. Use 32 random bytes, and keep it secret.
+  - formatPreserving: If true, pseudonyms have the format of the original value.
+
+Output
+  - A RedactionFunc for use with [RedactWithMap] and [RedactPathsWithMap].
+  - An error if the key is shorter than [MinPseudonymKeyLength] bytes (e.g. nil),
+    as pseudonyms made with such a key could be guessed.
+*/
+func Pseudonymize(key []byte, formatPreserving bool) (RedactionFunc, error) {
+	if len(key) < MinPseudonymKeyLength {
+		return nil, wraperror.Errorf(errForPackage, "key has %d bytes, want at least %d", len(key),
+			MinPseudonymKeyLength)
+	}
+
+	return func(jsonValue any) any {
+		return pseudonymizeValue(key, jsonValue, formatPreserving)
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+func (stream *keystream) next() byte {
+	if len(stream.block) == 0 {
+		counter := make([]byte, 8) //nolint:mnd
+		binary.BigEndian.PutUint64(counter, stream.counter)
+		stream.counter++
+
+		mac := hmac.New(sha256.New, stream.key)
+		mac.Write(counter)
+		mac.Write([]byte(stream.value))
+		stream.block = mac.Sum(nil)
+	}
+
+	result := stream.block[0]
+	stream.block = stream.block[1:]
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Return the redacted value for an original value.
+A RedactionFunc computes it from the original value; anything else is the redacted value itself.
+*/
+func computeRedactedValue(redactedValue any, originalValue any) any {
+	switch typedRedaction := redactedValue.(type) {
+	case RedactionFunc:
+		return typedRedaction(originalValue)
+	case func(any) any:
+		return typedRedaction(originalValue)
+	default:
+		return redactedValue
+	}
+}
+
+/*
+Replace each digit and letter of the original text, using a keystream seeded by the canonical text.
+If numeric, the first digit of a multi-digit integer part is never zero, so JSON numbers stay valid.
+*/
+func formatPreservingPseudonym(key []byte, canonical string, original string, numeric bool) string {
+	var result strings.Builder
+
+	stream := &keystream{
+		block:   nil,
+		counter: 0,
+		key:     key,
+		value:   formatPreservingDomain + canonical,
+	}
+
+	integerDigits := len(strings.TrimLeft(original, "-"))
+	if index := strings.IndexAny(original, ".eE"); index >= 0 {
+		integerDigits = len(strings.TrimLeft(original[:index], "-"))
+	}
+
+	isFirstDigit := true
+
+	for _, character := range original {
+		switch {
+		case character >= '0' && character <= '9':
+			if numeric && isFirstDigit && integerDigits > 1 {
+				result.WriteByte('1' + stream.next()%nonZeroDigits)
+			} else {
+				result.WriteByte('0' + stream.next()%digitCount)
+			}
+
+			isFirstDigit = false
+		case numeric:
+			result.WriteRune(character) // Keep "-", ".", "e", "E", and "+".
+		case unicode.IsUpper(character):
+			result.WriteByte('A' + stream.next()%letterCount)
+		case unicode.IsLetter(character):
+			result.WriteByte('a' + stream.next()%letterCount)
+		default:
+			result.WriteRune(character)
+		}
+	}
+
+	return result.String()
+}
+
+func pseudonymizePII(kind PIIKind, value string, rule PIIRule) string {
+	canonical := string(kind) + ":" + canonicalPII(kind, value)
+
+	if rule.FormatPreserving {
+		return formatPreservingPseudonym(rule.Key, canonical, value, false)
+	}
+
+	return string(kind) + "-" + pseudonymToken(rule.Key, canonical)
+}
+
+func pseudonymizeValue(key []byte, jsonValue any, formatPreserving bool) any {
+	switch typedJSON := jsonValue.(type) {
+	case nil:
+		return nil
+	case string:
+		if formatPreserving {
+			return formatPreservingPseudonym(key, typedJSON, typedJSON, false)
+		}
+
+		return pseudonymPrefix + pseudonymToken(key, typedJSON)
+	case json.Number:
+		if formatPreserving {
+			return json.Number(formatPreservingPseudonym(key, typedJSON.String(), typedJSON.String(), true))
+		}
+
+		return pseudonymPrefix + pseudonymToken(key, typedJSON.String())
+	default:
+		// Objects, arrays, and booleans are pseudonymized as a whole.
+
+		jsonText, err := json.Marshal(typedJSON)
+		if err != nil {
+			return nil
+		}
+
+		return pseudonymPrefix + pseudonymToken(key, string(jsonText))
+	}
+}
+
+func pseudonymToken(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(tokenDomain + value))
+
+	return hex.EncodeToString(mac.Sum(nil))[:hashLength]
+}
@@ -0,0 +1,197 @@
+package jsonutil_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	keyForPseudonym      = []byte("0123456789abcdef0123456789abcdef")
+	otherKeyForPseudonym = []byte("fedcba9876543210fedcba9876543210")
+)
+
+var testCasesForFormatPreservingPseudonym = []struct {
+	name    string
+	value   string
+	pattern string
+}{
+	{name: "ssn", value: `"294-66-9999"`, pattern: `^"\d{3}-\d{2}-\d{4}"$`},
+	{name: "name", value: `"Robert Smith"`, pattern: `^"[A-Z][a-z]{5} [A-Z][a-z]{4}"$`},
+	{name: "email", value: `"bsmith@work.com"`, pattern: `^"[a-z]{6}@[a-z]{4}\.[a-z]{3}"$`},
+	{name: "integer", value: `9007199254740993`, pattern: `^[1-9]\d{15}$`},
+	{name: "negative-decimal", value: `-12.50`, pattern: `^-[1-9]\d\.\d{2}$`},
+	{name: "exponent", value: `1.5E+10`, pattern: `^\d\.\dE\+\d{2}$`},
+	{name: "zero", value: `0`, pattern: `^\d$`},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestPseudonymize(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `[
+		{"RECORD_ID": "1", "SSN_NUMBER": "294-66-9999", "NOTE": null},
+		{"RECORD_ID": "2", "SSN_NUMBER": "294-66-9999", "NOTE": {"a": true}},
+		{"RECORD_ID": "3", "SSN_NUMBER": "111-22-3333", "NOTE": true}
+	]`
+	redactMap := map[string]any{
+		"SSN_NUMBER": newPseudonymizer(test, keyForPseudonym, false),
+		"NOTE":       newPseudonymizer(test, keyForPseudonym, false),
+	}
+
+	actual, err := jsonutil.RedactWithMap(jsonText, redactMap)
+	require.NoError(test, err)
+
+	var records []map[string]any
+	require.NoError(test, json.Unmarshal([]byte(actual), &records))
+	require.Len(test, records, 3)
+
+	assert.Regexp(test, `^PSEUDO-[0-9a-f]{16}$`, records[0]["SSN_NUMBER"])
+	assert.Equal(test, records[0]["SSN_NUMBER"], records[1]["SSN_NUMBER"])
+	assert.NotEqual(test, records[0]["SSN_NUMBER"], records[2]["SSN_NUMBER"])
+	assert.Nil(test, records[0]["NOTE"])
+	assert.Regexp(test, `^PSEUDO-[0-9a-f]{16}$`, records[1]["NOTE"])
+	assert.Regexp(test, `^PSEUDO-[0-9a-f]{16}$`, records[2]["NOTE"])
+	assert.NotContains(test, actual, "9999")
+}
+
+func TestPseudonymize_formatPreserving(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForFormatPreservingPseudonym {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			redactMap := map[string]any{"VALUE": newPseudonymizer(test, keyForPseudonym, true)}
+			jsonText := `{"VALUE": ` + testCase.value + `}`
+
+			actual1, err := jsonutil.RedactWithMap(jsonText, redactMap)
+			require.NoError(test, err)
+			actual2, err := jsonutil.RedactWithMap(jsonText, redactMap)
+			require.NoError(test, err)
+			assert.Equal(test, actual1, actual2)
+			assert.True(test, jsonutil.IsJSON(actual1))
+
+			var parsed map[string]json.RawMessage
+			require.NoError(test, json.Unmarshal([]byte(actual1), &parsed))
+			assert.Regexp(test, regexp.MustCompile(testCase.pattern), string(parsed["VALUE"]))
+		})
+	}
+}
+
+func TestPseudonymize_keyMatters(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"SSN_NUMBER": "294-66-9999"}`
+
+	actual1, err := jsonutil.RedactWithMap(jsonText, map[string]any{
+		"SSN_NUMBER": newPseudonymizer(test, keyForPseudonym, true),
+	})
+	require.NoError(test, err)
+	actual2, err := jsonutil.RedactWithMap(jsonText, map[string]any{
+		"SSN_NUMBER": newPseudonymizer(test, otherKeyForPseudonym, true),
+	})
+	require.NoError(test, err)
+	assert.NotEqual(test, actual1, actual2)
+}
+
+func TestPseudonymize_shortKey(test *testing.T) {
+	test.Parallel()
+
+	for _, key := range [][]byte{nil, []byte("0123456789abcde")} {
+		actual, err := jsonutil.Pseudonymize(key, false)
+		require.ErrorContains(test, err, "key has "+strconv.Itoa(len(key))+" bytes, want at least 16")
+		assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+		assert.Nil(test, actual)
+	}
+}
+
+func TestPseudonymize_withPaths(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"RECORDS": [{"SSN": "294-66-9999"}, {"SSN": "294-66-9999"}], "SSN": "294-66-9999"}`
+
+	actual, err := jsonutil.RedactPathsWithMap(jsonText, map[string]any{
+		"$.RECORDS[*].SSN": newPseudonymizer(test, keyForPseudonym, false),
+	})
+	require.NoError(test, err)
+
+	var document struct {
+		RECORDS []struct{ SSN string }
+		SSN     string
+	}
+
+	require.NoError(test, json.Unmarshal([]byte(actual), &document))
+	assert.Equal(test, document.RECORDS[0].SSN, document.RECORDS[1].SSN)
+	assert.Regexp(test, `^PSEUDO-`, document.RECORDS[0].SSN)
+	assert.Equal(test, "294-66-9999", document.SSN)
+}
+
+func TestRedactPII_pseudonymize(test *testing.T) {
+	test.Parallel()
+
+	rules := map[jsonutil.PIIKind]jsonutil.PIIRule{
+		jsonutil.PIISocialSecurity: {
+			Action:           jsonutil.PIIPseudonymize,
+			FormatPreserving: true,
+			Key:              keyForPseudonym,
+			Replacement:      "",
+		},
+		jsonutil.PIIEmail: {
+			Action:           jsonutil.PIIPseudonymize,
+			FormatPreserving: false,
+			Key:              keyForPseudonym,
+			Replacement:      "",
+		},
+	}
+
+	jsonText := `["SSN 294-66-9999", "SSN 294 66 9999", "bsmith@work.com", "BSmith@Work.com"]`
+
+	actual, err := jsonutil.RedactPII(jsonText, rules)
+	require.NoError(test, err)
+
+	var values []string
+	require.NoError(test, json.Unmarshal([]byte(actual), &values))
+	assert.Regexp(test, `^SSN \d{3}-\d{2}-\d{4}$`, values[0])
+	assert.Regexp(test, `^SSN \d{3} \d{2} \d{4}$`, values[1])
+	assert.Equal(test, values[0][4:7], values[1][4:7])
+	assert.NotEqual(test, "SSN 294-66-9999", values[0])
+	assert.Regexp(test, `^EMAIL-[0-9a-f]{16}$`, values[2])
+	assert.Equal(test, values[2], values[3])
+}
+
+func TestRedactPII_pseudonymizeShortKey(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"SSN_NUMBER": "294-66-9999"}`
+
+	for _, key := range [][]byte{nil, {}, []byte("0123456789abcde")} {
+		actual, err := jsonutil.RedactPII(jsonText, map[jsonutil.PIIKind]jsonutil.PIIRule{
+			jsonutil.PIISocialSecurity: {Action: jsonutil.PIIPseudonymize, FormatPreserving: false, Key: key, Replacement: ""},
+		})
+		require.ErrorContains(test, err, "SSN rule key has "+strconv.Itoa(len(key))+" bytes, want at least 16")
+		assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+		assert.Equal(test, jsonText, actual)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func newPseudonymizer(test *testing.T, key []byte, formatPreserving bool) jsonutil.RedactionFunc {
+	test.Helper()
+
+	result, err := jsonutil.Pseudonymize(key, formatPreserving)
+	require.NoError(test, err)
+
+	return result
+}
@@ -0,0 +1,321 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The GetBool method returns the boolean selected by the selector.
+
+Input
+  - selector: A selector (see [CompileSelector]) of at most one value.
+  - defaultValue: Returned if nothing, or only a JSON null, is selected.
+
+Output
+  - The boolean.
+  - An error if the selector is invalid, selects more than one value, or selects a value that is not a boolean.
+*/
+func (document *Document) GetBool(selector string, defaultValue bool) (bool, error) {
+	value, err := document.getOne(selector)
+	if err != nil || value == nil {
+		return defaultValue, wraperror.Errorf(err, "getOne")
+	}
+
+	result, isBool := value.(bool)
+	if !isBool {
+		return defaultValue, wrongType(selector, value, "boolean")
+	}
+
+	return result, nil
+}
+
+/*
+The GetFloat64 method returns the number selected by the selector.
+
+Input
+  - selector: A selector (see [CompileSelector]) of at most one value.
+  - defaultValue: Returned if nothing, or only a JSON null, is selected.
+
+Output
+  - The number.
+  - An error if the selector is invalid, selects more than one value, or selects a value that is not a number.
+*/
+func (document *Document) GetFloat64(selector string, defaultValue float64) (float64, error) {
+	value, err := document.getOne(selector)
+	if err != nil || value == nil {
+		return defaultValue, wraperror.Errorf(err, "getOne")
+	}
+
+	return toFloat64(selector, value, defaultValue)
+}
+
+/*
+The GetInt64 method returns the integer selected by the selector (e.g. an ENTITY_ID).
+
+Input
+  - selector: A selector (see [CompileSelector]) of at most one value.
+  - defaultValue: Returned if nothing, or only a JSON null, is selected.
+
+Output
+  - The integer.
+  - An error if the selector is invalid, selects more than one value, or selects a value that is not
+    an integer that fits in an int64.
+*/
+func (document *Document) GetInt64(selector string, defaultValue int64) (int64, error) {
+	value, err := document.getOne(selector)
+	if err != nil || value == nil {
+		return defaultValue, wraperror.Errorf(err, "getOne")
+	}
+
+	return toInt64(selector, value, defaultValue)
+}
+
+/*
+The GetInt64s method returns every integer selected by the selector, in document order
+(object members in order of their keys). JSON null values are skipped.
+
+Input
+  - selector: A selector (see [CompileSelector]), e.g. "$.RESOLVED_ENTITY.RECORDS[*].INTERNAL_ID".
+
+Output
+  - The integers. Empty if nothing is selected.
+  - An error if the selector is invalid, or selects a value that is not an integer that fits in an int64.
+*/
+func (document *Document) GetInt64s(selector string) ([]int64, error) {
+	values, err := document.Query(selector)
+	if err != nil {
+		return []int64{}, wraperror.Errorf(err, "Query")
+	}
+
+	result := make([]int64, 0, len(values))
+
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+
+		number, err := toInt64(selector, value, 0)
+		if err != nil {
+			return []int64{}, err
+		}
+
+		result = append(result, number)
+	}
+
+	return result, nil
+}
+
+/*
+The GetString method returns the string selected by the selector.
+
+Input
+  - selector: A selector (see [CompileSelector]) of at most one value.
+  - defaultValue: Returned if nothing, or only a JSON null, is selected.
+
+Output
+  - The string.
+  - An error if the selector is invalid, selects more than one value, or selects a value that is not a string.
+*/
+func (document *Document) GetString(selector string, defaultValue string) (string, error) {
+	value, err := document.getOne(selector)
+	if err != nil || value == nil {
+		return defaultValue, wraperror.Errorf(err, "getOne")
+	}
+
+	result, isString := value.(string)
+	if !isString {
+		return defaultValue, wrongType(selector, value, "string")
+	}
+
+	return result, nil
+}
+
+/*
+The GetStrings method returns every string selected by the selector, in document order
+(object members in order of their keys). JSON null values are skipped.
+
+Input
+  - selector: A selector (see [CompileSelector]), e.g. "$.RESOLVED_ENTITY.RECORDS[*].RECORD_ID".
+
+Output
+  - The strings. Empty if nothing is selected.
+  - An error if the selector is invalid, or selects a value that is not a string.
+*/
+func (document *Document) GetStrings(selector string) ([]string, error) {
+	values, err := document.Query(selector)
+	if err != nil {
+		return []string{}, wraperror.Errorf(err, "Query")
+	}
+
+	result := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+
+		text, isString := value.(string)
+		if !isString {
+			return []string{}, wrongType(selector, value, "string")
+		}
+
+		result = append(result, text)
+	}
+
+	return result, nil
+}
+
+/*
+The Query method returns every value selected by the selector, in document order
+(object members in order of their keys).
+
+Values are as unmarshalled by [encoding/json], except that numbers are [json.Number],
+so that large integers are exact.
+Selected arrays and objects are shared with the Document and should not be modified.
+
+Input
+  - selector: A selector (see [CompileSelector]).
+
+Output
+  - The selected values. Empty if nothing is selected.
+  - An error if the selector is invalid.
+*/
+func (document *Document) Query(selector string) ([]any, error) {
+	compiledSelector, err := CompileSelector(selector)
+	if err != nil {
+		return []any{}, wraperror.Errorf(err, "CompileSelector")
+	}
+
+	return selectValues(document.value, compiledSelector.steps, []any{}), nil
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The ParseDocument function parses JSON text once, so that it can be queried many times.
+For example, to get the RECORD_IDs of a Senzing get-entity response:
+
+	document, err := jsonutil.ParseDocument(response)
+	...
+	recordIDs, err := document.GetStrings("$.RESOLVED_ENTITY.RECORDS[*].RECORD_ID")
+
+Input
+  - jsonText: The JSON text to be queried.
+
+Output
+  - The parsed Document.
+  - An error if the text is not JSON.
+*/
+func ParseDocument(jsonText string) (*Document, error) {
+	value, err := unmarshalValue(jsonText)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "Unmarshal")
+	}
+
+	return &Document{value: value}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Return the only value selected. Selecting nothing is treated like selecting a JSON null.
+func (document *Document) getOne(selector string) (any, error) {
+	values, err := document.Query(selector)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "Query")
+	}
+
+	switch len(values) {
+	case 0:
+		return nil, nil //nolint:nilnil
+	case 1:
+		return values[0], nil
+	default:
+		return nil, wraperror.Errorf(errForPackage, "%s selects %d values, not one", selector, len(values))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Append the values selected by the steps, starting at jsonValue, to result.
+For recursive steps, a value's own matches come before those of its descendants.
+*/
+func selectValues(jsonValue any, steps []selectorStep, result []any) []any {
+	if len(steps) == 0 {
+		return append(result, jsonValue)
+	}
+
+	step := steps[0]
+
+	switch typedJSON := jsonValue.(type) {
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(typedJSON)) {
+			if step.matchesKey(key) {
+				result = selectValues(typedJSON[key], steps[1:], result)
+			}
+
+			if step.recursive {
+				result = selectValues(typedJSON[key], steps, result)
+			}
+		}
+	case []any:
+		for index, child := range typedJSON {
+			if step.matchesIndex(index) {
+				result = selectValues(child, steps[1:], result)
+			}
+
+			if step.recursive {
+				result = selectValues(child, steps, result)
+			}
+		}
+	}
+
+	return result
+}
+
+func toFloat64(selector string, value any, defaultValue float64) (float64, error) {
+	number, isNumber := value.(json.Number)
+	if !isNumber {
+		return defaultValue, wrongType(selector, value, "number")
+	}
+
+	result, err := number.Float64()
+	if err != nil {
+		return defaultValue, wraperror.Errorf(err, "%s is out of range: %s", selector, number)
+	}
+
+	return result, nil
+}
+
+func toInt64(selector string, value any, defaultValue int64) (int64, error) {
+	number, isNumber := value.(json.Number)
+	if !isNumber {
+		return defaultValue, wrongType(selector, value, "integer")
+	}
+
+	result, err := number.Int64()
+	if err != nil {
+		return defaultValue, wraperror.Errorf(err, "%s is not an int64: %s", selector, number)
+	}
+
+	return result, nil
+}
+
+func wrongType(selector string, value any, expected string) error {
+	return wraperror.Errorf(errForPackage, "%s: want %s, got %s %s", selector, expected, jsonType(value),
+		renderValue(value))
+}
@@ -0,0 +1,254 @@
+package jsonutil_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonTextForQuery = `{
+	"RESOLVED_ENTITY": {
+		"ENTITY_ID": 9007199254740993,
+		"ENTITY_NAME": "Robert Smith",
+		"IS_AMBIGUOUS": false,
+		"MATCH_SCORE": 0.95,
+		"RECORDS": [
+			{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "INTERNAL_ID": 1, "ERRULE_CODE": null},
+			{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1002", "INTERNAL_ID": 2, "ERRULE_CODE": "SF1_CNAME"},
+			{"DATA_SOURCE": "WATCHLIST", "RECORD_ID": "1003", "INTERNAL_ID": 3}
+		]
+	},
+	"RELATED_ENTITIES": [{"ENTITY_ID": 2, "RECORDS": [{"RECORD_ID": "2001"}]}]
+}`
+
+var testCasesForDocumentGetStrings = []struct {
+	name     string
+	selector string
+	expected []string
+}{
+	{
+		name:     "record-ids",
+		selector: "$.RESOLVED_ENTITY.RECORDS[*].RECORD_ID",
+		expected: []string{"1001", "1002", "1003"},
+	},
+	{
+		name:     "recursive-record-ids",
+		selector: "$..RECORD_ID",
+		expected: []string{"2001", "1001", "1002", "1003"}, // RELATED_ENTITIES sorts before RESOLVED_ENTITY.
+	},
+	{
+		name:     "nulls-and-missing-skipped",
+		selector: "$.RESOLVED_ENTITY.RECORDS[*].ERRULE_CODE",
+		expected: []string{"SF1_CNAME"},
+	},
+	{
+		name:     "nothing-selected",
+		selector: "$.NOT_THERE[*]",
+		expected: []string{},
+	},
+	{
+		name:     "key-order",
+		selector: "$.RESOLVED_ENTITY.RECORDS[0]./^(DATA_SOURCE|RECORD_ID)$/",
+		expected: []string{"CUSTOMERS", "1001"},
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test public methods
+// ----------------------------------------------------------------------------
+
+func TestDocument_GetBool(test *testing.T) {
+	test.Parallel()
+
+	document := parseDocumentForQuery(test)
+
+	actual, err := document.GetBool("$.RESOLVED_ENTITY.IS_AMBIGUOUS", true)
+	require.NoError(test, err)
+	assert.False(test, actual)
+
+	actual, err = document.GetBool("$.RESOLVED_ENTITY.IS_DISCLOSED", true)
+	require.NoError(test, err)
+	assert.True(test, actual)
+
+	actual, err = document.GetBool("$.RESOLVED_ENTITY.ENTITY_NAME", true)
+	require.Error(test, err)
+	assert.True(test, actual)
+	assert.Contains(test, err.Error(), `$.RESOLVED_ENTITY.ENTITY_NAME: want boolean, got string "Robert Smith"`)
+}
+
+func TestDocument_GetFloat64(test *testing.T) {
+	test.Parallel()
+
+	document := parseDocumentForQuery(test)
+
+	actual, err := document.GetFloat64("$.RESOLVED_ENTITY.MATCH_SCORE", -1)
+	require.NoError(test, err)
+	assert.InDelta(test, 0.95, actual, 0)
+
+	actual, err = document.GetFloat64("$.RESOLVED_ENTITY.RECORDS[0].ERRULE_CODE", -1)
+	require.NoError(test, err)
+	assert.InDelta(test, -1, actual, 0)
+
+	_, err = document.GetFloat64("$.RESOLVED_ENTITY.RECORDS", -1)
+	require.Error(test, err)
+}
+
+func TestDocument_GetFloat64_outOfRange(test *testing.T) {
+	test.Parallel()
+
+	document, err := jsonutil.ParseDocument(`{"a": 1e400}`)
+	require.NoError(test, err)
+
+	_, err = document.GetFloat64("$.a", 0)
+	require.Error(test, err)
+}
+
+func TestDocument_GetInt64(test *testing.T) {
+	test.Parallel()
+
+	document := parseDocumentForQuery(test)
+
+	actual, err := document.GetInt64("$.RESOLVED_ENTITY.ENTITY_ID", 0)
+	require.NoError(test, err)
+	assert.Equal(test, int64(9007199254740993), actual)
+
+	actual, err = document.GetInt64("$.RESOLVED_ENTITY.RECORDS[9].INTERNAL_ID", -1)
+	require.NoError(test, err)
+	assert.Equal(test, int64(-1), actual)
+
+	_, err = document.GetInt64("$.RESOLVED_ENTITY.MATCH_SCORE", 0)
+	require.Error(test, err)
+
+	_, err = document.GetInt64("$.RESOLVED_ENTITY.RECORD_ID", 0)
+	require.NoError(test, err)
+
+	_, err = document.GetInt64("$..ENTITY_ID", 0)
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), "$..ENTITY_ID selects 2 values, not one")
+}
+
+func TestDocument_GetInt64s(test *testing.T) {
+	test.Parallel()
+
+	document := parseDocumentForQuery(test)
+
+	actual, err := document.GetInt64s("$..INTERNAL_ID")
+	require.NoError(test, err)
+	assert.Equal(test, []int64{1, 2, 3}, actual)
+
+	actual, err = document.GetInt64s("$..ENTITY_ID")
+	require.NoError(test, err)
+	assert.Equal(test, []int64{2, 9007199254740993}, actual)
+
+	_, err = document.GetInt64s("$..RECORD_ID")
+	require.Error(test, err)
+
+	_, err = document.GetInt64s("$.[")
+	require.Error(test, err)
+}
+
+func TestDocument_GetString(test *testing.T) {
+	test.Parallel()
+
+	document := parseDocumentForQuery(test)
+
+	actual, err := document.GetString("$.RESOLVED_ENTITY.ENTITY_NAME", "")
+	require.NoError(test, err)
+	assert.Equal(test, "Robert Smith", actual)
+
+	actual, err = document.GetString("$.RESOLVED_ENTITY.RECORDS[0].ERRULE_CODE", "NONE")
+	require.NoError(test, err)
+	assert.Equal(test, "NONE", actual)
+
+	_, err = document.GetString("$.RESOLVED_ENTITY.ENTITY_ID", "")
+	require.Error(test, err)
+
+	_, err = document.GetString("RESOLVED_ENTITY", "")
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), `"function": "jsonutil.(*Document).GetString"`)
+	assert.Contains(test, err.Error(), `"text": "getOne"`)
+	assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+}
+
+func TestDocument_GetStrings(test *testing.T) {
+	test.Parallel()
+
+	document := parseDocumentForQuery(test)
+
+	for _, testCase := range testCasesForDocumentGetStrings {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := document.GetStrings(testCase.selector)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestDocument_GetStrings_wrongType(test *testing.T) {
+	test.Parallel()
+
+	document := parseDocumentForQuery(test)
+
+	_, err := document.GetStrings("$.RESOLVED_ENTITY.RECORDS[*].INTERNAL_ID")
+	require.Error(test, err)
+}
+
+func TestDocument_Query(test *testing.T) {
+	test.Parallel()
+
+	document := parseDocumentForQuery(test)
+
+	actual, err := document.Query("$.RELATED_ENTITIES[0]")
+	require.NoError(test, err)
+	assert.Equal(test, []any{map[string]any{
+		"ENTITY_ID": json.Number("2"),
+		"RECORDS":   []any{map[string]any{"RECORD_ID": "2001"}},
+	}}, actual)
+
+	actual, err = document.Query("$")
+	require.NoError(test, err)
+	assert.Len(test, actual, 1)
+
+	_, err = document.Query("$[")
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestParseDocument_badJSON(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.ParseDocument(badJSON)
+	require.Error(test, err)
+}
+
+func TestParseDocument_null(test *testing.T) {
+	test.Parallel()
+
+	document, err := jsonutil.ParseDocument(`null`)
+	require.NoError(test, err)
+
+	actual, err := document.GetString("$", "default")
+	require.NoError(test, err)
+	assert.Equal(test, "default", actual)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func parseDocumentForQuery(test *testing.T) *jsonutil.Document {
+	test.Helper()
+
+	document, err := jsonutil.ParseDocument(jsonTextForQuery)
+	require.NoError(test, err)
+
+	return document
+}
@@ -0,0 +1,499 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+const (
+	selectorIndex = iota
+	selectorName
+	selectorPattern
+	selectorWildcard
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A selectorStep matches the keys or indexes of one level of a JSON document,
+// or of any level below it if recursive.
+type selectorStep struct {
+	index     int
+	kind      int
+	name      string
+	pattern   *regexp.Regexp
+	recursive bool
+}
+
+// A selectorAction is applied to each selected value.
+// It returns the replacement value and whether the value is kept.
+type selectorAction func(jsonValue any) (any, bool)
+
+type selectorWithAction struct {
+	action   selectorAction
+	selector *Selector
+}
+
+// A removedValue marks a value removed by a selectorAction, until every action has been applied.
+// Removing it at once would shift the indexes of later elements, that the other selectors must still find.
+type removedValue struct{}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The String method returns the text the Selector was compiled from.
+
+Output
+  - The selector text.
+*/
+func (selector *Selector) String() string {
+	return selector.text
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The CompileSelector function parses a JSONPath-like selector.
+
+A selector starts with "$" (the whole document) followed by steps:
+
+	.NAME or ['NAME']       The member named NAME.
+	.* or [*]               Every member of an object, or every element of an array.
+	[3]                     The element of an array at index 3.
+	./REGEX/ or [/REGEX/]   Every member whose name matches the regular expression.
+	..NAME, ..*, ../REGEX/  As above, but at any depth below the current value.
+
+For example, "$.RESOLVED_ENTITY.RECORDS[*].JSON_DATA.SSN_NUMBER" selects the SSN_NUMBER of
+the JSON_DATA of every record of the resolved entity, and "$..JSON_DATA./^SSN/" selects every
+member whose name starts with "SSN" in any JSON_DATA.
+
+Input
+  - selector: The selector text.
+
+Output
+  - The compiled Selector.
+*/
+func CompileSelector(selector string) (*Selector, error) {
+	if !strings.HasPrefix(selector, "$") {
+		return nil, wraperror.Errorf(errForPackage, "selector must start with $: %s", selector)
+	}
+
+	result := &Selector{
+		steps: []selectorStep{},
+		text:  selector,
+	}
+
+	remaining := selector[1:]
+
+	for len(remaining) > 0 {
+		step, rest, err := parseSelectorStep(remaining)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "invalid selector %s at %s", selector, remaining)
+		}
+
+		result.steps = append(result.steps, step)
+		remaining = rest
+	}
+
+	return result, nil
+}
+
+/*
+The RedactPaths function replaces the values selected by the selectors (see [CompileSelector])
+with JSON null values.
+Unlike [Redact], which matches keys by name anywhere in the document, only the selected values are redacted.
+
+Input
+  - jsonText: The JSON text to be redacted.
+  - selectors: Selectors of the values to be redacted.
+
+Output
+  - The JSON text representing the redacted JSON.
+  - An error if a selector is invalid or a failure occurred in unmarshalling the specified text.
+*/
+func RedactPaths(jsonText string, selectors ...string) (string, error) {
+	redactMap := map[string]any{}
+	for _, selector := range selectors {
+		redactMap[selector] = nil
+	}
+
+	return RedactPathsWithMap(jsonText, redactMap)
+}
+
+/*
+The RedactPathsWithMap function replaces the values selected by the keys of the redaction map
+(see [CompileSelector]) with the corresponding values from the redaction map.
+If nil, then a JSON null will be used.
+If a redacted value is a [RedactionFunc] (e.g. [Pseudonymize]), it is called with the
+original value to compute the redacted value.
+
+Input
+  - jsonText: The JSON text to be redacted.
+  - redactMap: The map of selectors to values to be used for redaction.
+
+Output
+  - The JSON text representing the redacted JSON.
+  - An error if a selector is invalid, or a failure occurred in unmarshalling the specified text or
+    marshalling the redacted JSON.
+*/
+func RedactPathsWithMap(jsonText string, redactMap map[string]any) (string, error) {
+	actions := make([]selectorWithAction, 0, len(redactMap))
+
+	for selectorText, redactedValue := range redactMap {
+		selector, err := CompileSelector(selectorText)
+		if err != nil {
+			return jsonText, wraperror.Errorf(err, "CompileSelector")
+		}
+
+		actions = append(actions, selectorWithAction{
+			action: func(jsonValue any) (any, bool) {
+				return computeRedactedValue(redactedValue, jsonValue), true
+			},
+			selector: selector,
+		})
+	}
+
+	result, err := applySelectors(jsonText, actions)
+
+	return result, wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+/*
+The StripPaths function removes the values selected by the selectors (see [CompileSelector]).
+Selected object members are removed from their object; selected array elements are removed from their array.
+Unlike [Strip], which matches keys by name anywhere in the document, only the selected values are removed.
+Every selector selects from the original document, so "$.A[0]" and "$.A[2]" remove the first and third elements.
+
+Input
+  - jsonText: The JSON text to be modified.
+  - selectors: Selectors of the values to be removed.
+
+Output
+  - The JSON text representing the modified JSON.
+  - An error if a selector is invalid or a failure occurred in unmarshalling the specified text.
+*/
+func StripPaths(jsonText string, selectors ...string) (string, error) {
+	actions := make([]selectorWithAction, 0, len(selectors))
+
+	for _, selectorText := range selectors {
+		selector, err := CompileSelector(selectorText)
+		if err != nil {
+			return jsonText, wraperror.Errorf(err, "CompileSelector")
+		}
+
+		actions = append(actions, selectorWithAction{
+			action: func(jsonValue any) (any, bool) {
+				return jsonValue, false
+			},
+			selector: selector,
+		})
+	}
+
+	result, err := applySelectors(jsonText, actions)
+
+	return result, wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+func (step *selectorStep) matchesIndex(index int) bool {
+	switch step.kind {
+	case selectorIndex:
+		return step.index == index
+	case selectorWildcard:
+		return true
+	default:
+		return false
+	}
+}
+
+func (step *selectorStep) matchesKey(key string) bool {
+	switch step.kind {
+	case selectorName:
+		return step.name == key
+	case selectorPattern:
+		return step.pattern.MatchString(key)
+	case selectorWildcard:
+		return true
+	default:
+		return false
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Apply each action to the values selected by its selector.
+Actions are applied in selector order, so overlapping selectors give consistent results.
+*/
+func applySelectors(jsonText string, actions []selectorWithAction) (string, error) {
+	slices.SortFunc(actions, func(action1 selectorWithAction, action2 selectorWithAction) int {
+		return strings.Compare(action1.selector.text, action2.selector.text)
+	})
+
+	parsedJSON, err := unmarshal(jsonText)
+	if err != nil {
+		return jsonText, wraperror.Errorf(err, "Unmarshal")
+	}
+
+	var jsonValue any
+	if parsedJSON != nil {
+		jsonValue = *parsedJSON
+	}
+
+	// Every selector selects from the original document: removed values are only marked,
+	// then removed once all the actions have been applied.
+
+	for _, action := range actions {
+		jsonValue = applySteps(jsonValue, action.selector.steps, action.action)
+	}
+
+	if isRemoved(jsonValue) || jsonValue == nil {
+		return Null, nil
+	}
+
+	result, err := json.Marshal(dropRemoved(jsonValue))
+
+	return string(result), wraperror.Error(err)
+}
+
+/*
+Apply the action to the values selected by the steps, starting at jsonValue.
+Returns the (possibly replaced) jsonValue, or a removedValue if it is not kept.
+Values already removed are not selected again.
+*/
+func applySteps(jsonValue any, steps []selectorStep, action selectorAction) any {
+	if isRemoved(jsonValue) {
+		return jsonValue
+	}
+
+	if len(steps) == 0 {
+		newValue, keep := action(jsonValue)
+		if !keep {
+			return removedValue{}
+		}
+
+		return newValue
+	}
+
+	step := steps[0]
+
+	switch typedJSON := jsonValue.(type) {
+	case map[string]any:
+		for key, child := range typedJSON {
+			// For recursive steps, descend before applying the action so that replacement values are not visited.
+
+			if step.recursive {
+				child = applySteps(child, steps, action)
+				typedJSON[key] = child
+			}
+
+			if step.matchesKey(key) {
+				typedJSON[key] = applySteps(child, steps[1:], action)
+			}
+		}
+	case []any:
+		for index, child := range typedJSON {
+			if step.recursive {
+				child = applySteps(child, steps, action)
+				typedJSON[index] = child
+			}
+
+			if step.matchesIndex(index) {
+				typedJSON[index] = applySteps(child, steps[1:], action)
+			}
+		}
+	}
+
+	return jsonValue
+}
+
+// Remove the values marked by applySteps from objects and arrays, at any depth.
+func dropRemoved(jsonValue any) any {
+	switch typedJSON := jsonValue.(type) {
+	case map[string]any:
+		for key, child := range typedJSON {
+			if isRemoved(child) {
+				delete(typedJSON, key)
+			} else {
+				typedJSON[key] = dropRemoved(child)
+			}
+		}
+	case []any:
+		result := make([]any, 0, len(typedJSON))
+
+		for _, child := range typedJSON {
+			if !isRemoved(child) {
+				result = append(result, dropRemoved(child))
+			}
+		}
+
+		return result
+	}
+
+	return jsonValue
+}
+
+func isRemoved(jsonValue any) bool {
+	_, isRemovedValue := jsonValue.(removedValue)
+
+	return isRemovedValue
+}
+
+/*
+Parse one step from the front of the selector text.
+Returns the step and the remaining selector text.
+*/
+func parseSelectorStep(selector string) (selectorStep, string, error) {
+	var result selectorStep
+
+	switch {
+	case strings.HasPrefix(selector, "..["):
+		result.recursive = true
+
+		return parseBracketStep(selector[2:], result)
+	case strings.HasPrefix(selector, ".."):
+		result.recursive = true
+
+		return parseDotStep(selector[2:], result)
+	case strings.HasPrefix(selector, "."):
+		return parseDotStep(selector[1:], result)
+	case strings.HasPrefix(selector, "["):
+		return parseBracketStep(selector, result)
+	default:
+		return result, selector, wraperror.Errorf(errForPackage, "expected . or [")
+	}
+}
+
+// Parse ".NAME", ".*", or "./REGEX/" (without the leading ".").
+func parseDotStep(selector string, result selectorStep) (selectorStep, string, error) {
+	switch {
+	case strings.HasPrefix(selector, "*"):
+		result.kind = selectorWildcard
+
+		return result, selector[1:], nil
+	case strings.HasPrefix(selector, "/"):
+		return parsePatternStep(selector, result)
+	}
+
+	end := strings.IndexAny(selector, ".[")
+	if end < 0 {
+		end = len(selector)
+	}
+
+	if end == 0 {
+		return result, selector, wraperror.Errorf(errForPackage, "missing name")
+	}
+
+	result.kind = selectorName
+	result.name = selector[:end]
+
+	return result, selector[end:], nil
+}
+
+// Parse "[*]", "[3]", "['NAME']", "[\"NAME\"]", or "[/REGEX/]".
+func parseBracketStep(selector string, result selectorStep) (selectorStep, string, error) {
+	var err error
+
+	selector = selector[1:]
+
+	switch {
+	case strings.HasPrefix(selector, "*"):
+		result.kind = selectorWildcard
+		selector = selector[1:]
+	case strings.HasPrefix(selector, "/"):
+		result, selector, err = parsePatternStep(selector, result)
+	case strings.HasPrefix(selector, "'"), strings.HasPrefix(selector, `"`):
+		result.kind = selectorName
+		result.name, selector, err = parseQuotedName(selector)
+	default:
+		end := strings.Index(selector, "]")
+		if end < 0 {
+			return result, selector, wraperror.Errorf(errForPackage, "missing ]")
+		}
+
+		result.kind = selectorIndex
+
+		result.index, err = strconv.Atoi(selector[:end])
+		if err != nil || result.index < 0 {
+			return result, selector, wraperror.Errorf(errForPackage, "invalid array index %s", selector[:end])
+		}
+
+		selector = selector[end:]
+	}
+
+	if err != nil {
+		return result, selector, err
+	}
+
+	if !strings.HasPrefix(selector, "]") {
+		return result, selector, wraperror.Errorf(errForPackage, "missing ]")
+	}
+
+	return result, selector[1:], nil
+}
+
+// Parse "/REGEX/". A "/" within the regular expression is written as "\/".
+func parsePatternStep(selector string, result selectorStep) (selectorStep, string, error) {
+	var pattern strings.Builder
+
+	for index := 1; index < len(selector); index++ {
+		switch {
+		case selector[index] == '\\' && index+1 < len(selector) && selector[index+1] == '/':
+			pattern.WriteByte('/')
+
+			index++
+		case selector[index] == '/':
+			compiled, err := regexp.Compile(pattern.String())
+			if err != nil {
+				return result, selector, wraperror.Errorf(err, "regexp.Compile")
+			}
+
+			result.kind = selectorPattern
+			result.pattern = compiled
+
+			return result, selector[index+1:], nil
+		default:
+			pattern.WriteByte(selector[index])
+		}
+	}
+
+	return result, selector, wraperror.Errorf(errForPackage, "missing closing /")
+}
+
+// Parse 'NAME' or "NAME". A quote or backslash within the name is escaped with a backslash.
+func parseQuotedName(selector string) (string, string, error) {
+	var name strings.Builder
+
+	quote := selector[0]
+
+	for index := 1; index < len(selector); index++ {
+		switch selector[index] {
+		case '\\':
+			if index+1 < len(selector) {
+				index++
+				name.WriteByte(selector[index])
+			}
+		case quote:
+			return name.String(), selector[index+1:], nil
+		default:
+			name.WriteByte(selector[index])
+		}
+	}
+
+	return "", selector, wraperror.Errorf(errForPackage, "missing closing quote")
+}
@@ -0,0 +1,206 @@
+package jsonutil_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonTextForSelector = `{
+	"RESOLVED_ENTITY": {
+		"ENTITY_ID": 9007199254740993,
+		"NAME": "Joe Schmoe",
+		"RECORDS": [
+			{"DATA_SOURCE": "CUSTOMERS", "JSON_DATA": {"NAME": "Joe", "SSN_NUMBER": "111-22-3333", "SSN_LAST4": "3333"}},
+			{"DATA_SOURCE": "WATCHLIST", "JSON_DATA": {"NAME": "Joseph", "SSN_NUMBER": "444-55-6666"}}
+		]
+	},
+	"RELATED_ENTITIES": [{"ENTITY_ID": 2, "NAME": "Jane"}]
+}`
+
+var testCasesForRedactPaths = []struct {
+	name      string
+	selectors []string
+	expected  string
+}{
+	{
+		name:      "exact-path",
+		selectors: []string{"$.RESOLVED_ENTITY.RECORDS[*].JSON_DATA.SSN_NUMBER"},
+		expected:  `{"RELATED_ENTITIES":[{"ENTITY_ID":2,"NAME":"Jane"}],"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"NAME":"Joe Schmoe","RECORDS":[{"DATA_SOURCE":"CUSTOMERS","JSON_DATA":{"NAME":"Joe","SSN_LAST4":"3333","SSN_NUMBER":null}},{"DATA_SOURCE":"WATCHLIST","JSON_DATA":{"NAME":"Joseph","SSN_NUMBER":null}}]}}`,
+	},
+	{
+		name:      "array-index",
+		selectors: []string{"$.RESOLVED_ENTITY.RECORDS[1].JSON_DATA.NAME"},
+		expected:  `{"RELATED_ENTITIES":[{"ENTITY_ID":2,"NAME":"Jane"}],"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"NAME":"Joe Schmoe","RECORDS":[{"DATA_SOURCE":"CUSTOMERS","JSON_DATA":{"NAME":"Joe","SSN_LAST4":"3333","SSN_NUMBER":"111-22-3333"}},{"DATA_SOURCE":"WATCHLIST","JSON_DATA":{"NAME":null,"SSN_NUMBER":"444-55-6666"}}]}}`,
+	},
+	{
+		name:      "recursive-descent",
+		selectors: []string{"$..JSON_DATA.NAME"},
+		expected:  `{"RELATED_ENTITIES":[{"ENTITY_ID":2,"NAME":"Jane"}],"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"NAME":"Joe Schmoe","RECORDS":[{"DATA_SOURCE":"CUSTOMERS","JSON_DATA":{"NAME":null,"SSN_LAST4":"3333","SSN_NUMBER":"111-22-3333"}},{"DATA_SOURCE":"WATCHLIST","JSON_DATA":{"NAME":null,"SSN_NUMBER":"444-55-6666"}}]}}`,
+	},
+	{
+		name:      "key-pattern",
+		selectors: []string{"$..JSON_DATA./^SSN_/"},
+		expected:  `{"RELATED_ENTITIES":[{"ENTITY_ID":2,"NAME":"Jane"}],"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"NAME":"Joe Schmoe","RECORDS":[{"DATA_SOURCE":"CUSTOMERS","JSON_DATA":{"NAME":"Joe","SSN_LAST4":null,"SSN_NUMBER":null}},{"DATA_SOURCE":"WATCHLIST","JSON_DATA":{"NAME":"Joseph","SSN_NUMBER":null}}]}}`,
+	},
+	{
+		name:      "bracket-notation",
+		selectors: []string{`$['RELATED_ENTITIES'][*]["NAME"]`, "$.RESOLVED_ENTITY[/^NAME$/]"},
+		expected:  `{"RELATED_ENTITIES":[{"ENTITY_ID":2,"NAME":null}],"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"NAME":null,"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","JSON_DATA":{"NAME":"Joe","SSN_LAST4":"3333","SSN_NUMBER":"111-22-3333"}},{"DATA_SOURCE":"WATCHLIST","JSON_DATA":{"NAME":"Joseph","SSN_NUMBER":"444-55-6666"}}]}}`,
+	},
+	{
+		name:      "no-match",
+		selectors: []string{"$.NOT_THERE.NAME", "$.RESOLVED_ENTITY.RECORDS[9]", "$.RESOLVED_ENTITY.NAME.NOT_AN_OBJECT"},
+		expected:  `{"RELATED_ENTITIES":[{"ENTITY_ID":2,"NAME":"Jane"}],"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"NAME":"Joe Schmoe","RECORDS":[{"DATA_SOURCE":"CUSTOMERS","JSON_DATA":{"NAME":"Joe","SSN_LAST4":"3333","SSN_NUMBER":"111-22-3333"}},{"DATA_SOURCE":"WATCHLIST","JSON_DATA":{"NAME":"Joseph","SSN_NUMBER":"444-55-6666"}}]}}`,
+	},
+	{
+		name:      "root",
+		selectors: []string{"$"},
+		expected:  `null`,
+	},
+}
+
+var testCasesForStripPaths = []struct {
+	name      string
+	selectors []string
+	expected  string
+}{
+	{
+		name:      "exact-path",
+		selectors: []string{"$.RESOLVED_ENTITY.RECORDS[*].JSON_DATA.SSN_NUMBER", "$.RELATED_ENTITIES"},
+		expected:  `{"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"NAME":"Joe Schmoe","RECORDS":[{"DATA_SOURCE":"CUSTOMERS","JSON_DATA":{"NAME":"Joe","SSN_LAST4":"3333"}},{"DATA_SOURCE":"WATCHLIST","JSON_DATA":{"NAME":"Joseph"}}]}}`,
+	},
+	{
+		name:      "array-element",
+		selectors: []string{"$.RESOLVED_ENTITY.RECORDS[0]", "$.RELATED_ENTITIES[*]"},
+		expected:  `{"RELATED_ENTITIES":[],"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"NAME":"Joe Schmoe","RECORDS":[{"DATA_SOURCE":"WATCHLIST","JSON_DATA":{"NAME":"Joseph","SSN_NUMBER":"444-55-6666"}}]}}`,
+	},
+	{
+		name:      "recursive-wildcard",
+		selectors: []string{"$..JSON_DATA", "$..ENTITY_ID"},
+		expected:  `{"RELATED_ENTITIES":[{"NAME":"Jane"}],"RESOLVED_ENTITY":{"NAME":"Joe Schmoe","RECORDS":[{"DATA_SOURCE":"CUSTOMERS"},{"DATA_SOURCE":"WATCHLIST"}]}}`,
+	},
+}
+
+var testCasesForCompileSelectorErrors = []struct {
+	name     string
+	selector string
+}{
+	{name: "no-root", selector: "RESOLVED_ENTITY.NAME"},
+	{name: "missing-name", selector: "$.RESOLVED_ENTITY."},
+	{name: "missing-bracket", selector: "$.RECORDS[0"},
+	{name: "bad-index", selector: "$.RECORDS[-1]"},
+	{name: "bad-regex", selector: "$./[/"},
+	{name: "unterminated-regex", selector: "$./^SSN"},
+	{name: "unterminated-quote", selector: "$['NAME]"},
+	{name: "unterminated-double-quote", selector: `$["NAME]`},
+	{name: "garbage", selector: "$NAME"},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestCompileSelector(test *testing.T) {
+	test.Parallel()
+
+	selectorText := `$..JSON_DATA['SSN NUMBER'][/a\/b/][*][3]`
+
+	selector, err := jsonutil.CompileSelector(selectorText)
+	require.NoError(test, err)
+	assert.Equal(test, selectorText, selector.String())
+}
+
+func TestCompileSelector_errors(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForCompileSelectorErrors {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			_, err := jsonutil.CompileSelector(testCase.selector)
+			require.Error(test, err)
+			assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+		})
+	}
+}
+
+func TestRedactPaths(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForRedactPaths {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.RedactPaths(jsonTextForSelector, testCase.selectors...)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestRedactPaths_badJSON(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.RedactPaths(badJSON, "$.foo")
+	require.Error(test, err)
+	assert.Equal(test, badJSON, actual)
+}
+
+func TestRedactPaths_badSelector(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.RedactPaths(jsonTextForSelector, "$.RECORDS[")
+	require.Error(test, err)
+}
+
+func TestRedactPathsWithMap(test *testing.T) {
+	test.Parallel()
+
+	jsonText := `{"NAME": "Joe", "NESTED": {"NAME": "Joe", "SSN": "111-22-3333"}}`
+	expected := `{"NAME":"Joe","NESTED":{"NAME":"xxx","SSN":"***-**-****"}}`
+
+	actual, err := jsonutil.RedactPathsWithMap(jsonText, map[string]any{
+		"$.NESTED.NAME": "xxx",
+		"$.NESTED.SSN":  "***-**-****",
+	})
+	require.NoError(test, err)
+	assert.Equal(test, expected, actual)
+}
+
+func TestStripPaths(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForStripPaths {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.StripPaths(jsonTextForSelector, testCase.selectors...)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+// Every selector selects from the original document, so removing an element does not shift the others.
+func TestStripPaths_sameArray(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.StripPaths(`{"A": ["a", "b", "c", "d"]}`, "$.A[0]", "$.A[2]")
+	require.NoError(test, err)
+	assert.Equal(test, `{"A":["b","d"]}`, actual)
+
+	actual, err = jsonutil.StripPaths(`{"A": [["a", "b"], "c"]}`, "$.A[0][1]", "$.A[0]", "$.A[1]")
+	require.NoError(test, err)
+	assert.Equal(test, `{"A":[]}`, actual)
+}
+
+func TestStripPaths_badSelector(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.StripPaths(jsonTextForSelector, "NAME")
+	require.Error(test, err)
+}
@@ -0,0 +1,228 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// Order of JSON types when sorting by key. A missing key sorts first.
+const (
+	sortRankMissing = iota
+	sortRankNull
+	sortRankBoolean
+	sortRankNumber
+	sortRankString
+	sortRankArray
+	sortRankObject
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A sortableElement is an array element with its sort keys, computed once rather than on every comparison.
+type sortableElement struct {
+	isObject  bool
+	jsonText  []byte
+	keyValues []any
+	keyRanks  []int
+	value     any
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NormalizeAndSortWithOptions function normalizes JSON text like [NormalizeAndSort],
+but sorts only the arrays selected by options.Paths, and can sort arrays of objects by key.
+
+If options.Paths is empty, every array is sorted, as by [NormalizeAndSort].
+Otherwise only the arrays selected by the paths are sorted; their elements, and all other arrays, keep their order.
+To sort arrays at any depth under a key, use a recursive selector (e.g. "$..RECORDS").
+
+If options.ByKeys is not empty, objects in a sorted array are ordered by the value of the first key,
+then the second key, and so on (e.g. "DATA_SOURCE", then "RECORD_ID").
+Numbers are compared by value and strings by text; objects without a key sort before objects with it.
+Remaining ties, and elements that are not objects, are ordered as by [NormalizeAndSort].
+
+Input
+  - jsonText: The JSON text to be normalized and sorted.
+  - options: Which arrays to sort, and how.
+
+Output
+  - The JSON text that is the normalized representation of the specified text.
+  - An error if a selector is invalid or a failure occurred in interpretting/normalizing the specified text.
+*/
+func NormalizeAndSortWithOptions(jsonText string, options SortOptions) (string, error) {
+	if len(options.Paths) == 0 {
+		document, err := unmarshalValue(jsonText)
+		if err != nil {
+			return jsonText, wraperror.Errorf(err, "Unmarshal")
+		}
+
+		sortValue(document, options.ByKeys)
+
+		normalizedJSON, err := json.Marshal(document)
+
+		return string(normalizedJSON), wraperror.Error(err)
+	}
+
+	actions := make([]selectorWithAction, 0, len(options.Paths))
+
+	for _, selectorText := range options.Paths {
+		selector, err := CompileSelector(selectorText)
+		if err != nil {
+			return jsonText, wraperror.Errorf(err, "CompileSelector")
+		}
+
+		actions = append(actions, selectorWithAction{
+			action: func(jsonValue any) (any, bool) {
+				if jsonArray, isArray := jsonValue.([]any); isArray {
+					sortArrayElements(jsonArray, options.ByKeys)
+				}
+
+				return jsonValue, true
+			},
+			selector: selector,
+		})
+	}
+
+	result, err := applySelectors(jsonText, actions)
+
+	return result, wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func compareKeyValues(rank1 int, value1 any, rank2 int, value2 any) int {
+	if rank1 != rank2 {
+		return rank1 - rank2
+	}
+
+	switch typedValue1 := value1.(type) {
+	case bool:
+		typedValue2, _ := value2.(bool)
+
+		switch {
+		case typedValue1 == typedValue2:
+			return 0
+		case typedValue2:
+			return -1
+		default:
+			return 1
+		}
+	case json.Number:
+		typedValue2, _ := value2.(json.Number)
+
+		rational1, isRational1 := parseRational(typedValue1)
+		rational2, isRational2 := parseRational(typedValue2)
+
+		if isRational1 && isRational2 {
+			return rational1.Cmp(rational2)
+		}
+
+		return strings.Compare(typedValue1.String(), typedValue2.String())
+	case string:
+		typedValue2, _ := value2.(string)
+
+		return strings.Compare(typedValue1, typedValue2)
+	case nil:
+		return 0
+	default:
+		return strings.Compare(renderValue(value1), renderValue(value2))
+	}
+}
+
+/*
+Order elements with null values first, then by key values if both are objects, then by their JSON text.
+Objects are last in JSON text order (their text starts with "{"), so the order is consistent.
+*/
+func compareSortableElements(element1 sortableElement, element2 sortableElement) int {
+	switch {
+	case element1.value == nil && element2.value == nil:
+		return 0
+	case element1.value == nil:
+		return -1
+	case element2.value == nil:
+		return 1
+	}
+
+	if element1.isObject && element2.isObject {
+		for index := range element1.keyValues {
+			result := compareKeyValues(
+				element1.keyRanks[index], element1.keyValues[index],
+				element2.keyRanks[index], element2.keyValues[index],
+			)
+			if result != 0 {
+				return result
+			}
+		}
+	}
+
+	return bytes.Compare(element1.jsonText, element2.jsonText)
+}
+
+// Sort the elements of the array, but not the contents of the elements.
+func sortArrayElements(jsonArray []any, byKeys []string) {
+	elements := make([]sortableElement, len(jsonArray))
+
+	for index, value := range jsonArray {
+		jsonText, _ := json.Marshal(value)
+		element := sortableElement{
+			isObject:  false,
+			jsonText:  jsonText,
+			keyValues: nil,
+			keyRanks:  nil,
+			value:     value,
+		}
+
+		if jsonObject, isObject := value.(map[string]any); isObject && len(byKeys) > 0 {
+			element.isObject = true
+			element.keyValues = make([]any, len(byKeys))
+			element.keyRanks = make([]int, len(byKeys))
+
+			for keyIndex, key := range byKeys {
+				keyValue, hasKey := jsonObject[key]
+
+				element.keyValues[keyIndex] = keyValue
+				element.keyRanks[keyIndex] = sortRankMissing
+
+				if hasKey {
+					element.keyRanks[keyIndex] = sortRank(keyValue)
+				}
+			}
+		}
+
+		elements[index] = element
+	}
+
+	slices.SortStableFunc(elements, compareSortableElements)
+
+	for index, element := range elements {
+		jsonArray[index] = element.value
+	}
+}
+
+func sortRank(jsonValue any) int {
+	switch jsonValue.(type) {
+	case nil:
+		return sortRankNull
+	case bool:
+		return sortRankBoolean
+	case json.Number:
+		return sortRankNumber
+	case string:
+		return sortRankString
+	case []any:
+		return sortRankArray
+	default:
+		return sortRankObject
+	}
+}
@@ -0,0 +1,117 @@
+package jsonutil_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonTextForSort = `{
+	"MATCH_KEYS": ["+NAME", "+DOB", "+ADDRESS"],
+	"RECORDS": [
+		{"DATA_SOURCE": "WATCHLIST", "RECORD_ID": "10", "FEATURES": ["b", "a"]},
+		{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "2", "FEATURES": ["d", "c"]},
+		{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "10", "FEATURES": []}
+	],
+	"RELATED_ENTITIES": [{"ENTITY_ID": 10, "NAME": "Jane"}, {"ENTITY_ID": 9, "NAME": "Jim"}, {"NAME": "Unknown"}]
+}`
+
+var testCasesForNormalizeAndSortWithOptions = []struct {
+	name     string
+	jsonText string
+	options  jsonutil.SortOptions
+	expected string
+}{
+	{
+		name:     "default",
+		jsonText: jsonTextForSort,
+		options:  jsonutil.SortOptions{ByKeys: nil, Paths: nil},
+		expected: `{"MATCH_KEYS":["+ADDRESS","+DOB","+NAME"],"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","FEATURES":["c","d"],"RECORD_ID":"2"},{"DATA_SOURCE":"CUSTOMERS","FEATURES":[],"RECORD_ID":"10"},{"DATA_SOURCE":"WATCHLIST","FEATURES":["a","b"],"RECORD_ID":"10"}],"RELATED_ENTITIES":[{"ENTITY_ID":10,"NAME":"Jane"},{"ENTITY_ID":9,"NAME":"Jim"},{"NAME":"Unknown"}]}`,
+	},
+	{
+		name:     "paths",
+		jsonText: jsonTextForSort,
+		options:  jsonutil.SortOptions{ByKeys: nil, Paths: []string{"$.RECORDS", "$.RECORDS[*].FEATURES"}},
+		expected: `{"MATCH_KEYS":["+NAME","+DOB","+ADDRESS"],"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","FEATURES":["c","d"],"RECORD_ID":"2"},{"DATA_SOURCE":"CUSTOMERS","FEATURES":[],"RECORD_ID":"10"},{"DATA_SOURCE":"WATCHLIST","FEATURES":["a","b"],"RECORD_ID":"10"}],"RELATED_ENTITIES":[{"ENTITY_ID":10,"NAME":"Jane"},{"ENTITY_ID":9,"NAME":"Jim"},{"NAME":"Unknown"}]}`,
+	},
+	{
+		name:     "paths-do-not-recurse",
+		jsonText: jsonTextForSort,
+		options:  jsonutil.SortOptions{ByKeys: nil, Paths: []string{"$.RECORDS"}},
+		expected: `{"MATCH_KEYS":["+NAME","+DOB","+ADDRESS"],"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","FEATURES":["d","c"],"RECORD_ID":"2"},{"DATA_SOURCE":"CUSTOMERS","FEATURES":[],"RECORD_ID":"10"},{"DATA_SOURCE":"WATCHLIST","FEATURES":["b","a"],"RECORD_ID":"10"}],"RELATED_ENTITIES":[{"ENTITY_ID":10,"NAME":"Jane"},{"ENTITY_ID":9,"NAME":"Jim"},{"NAME":"Unknown"}]}`,
+	},
+	{
+		name:     "by-keys",
+		jsonText: jsonTextForSort,
+		options:  jsonutil.SortOptions{ByKeys: []string{"ENTITY_ID", "DATA_SOURCE", "RECORD_ID"}, Paths: []string{"$.RECORDS", "$.RELATED_ENTITIES"}},
+		expected: `{"MATCH_KEYS":["+NAME","+DOB","+ADDRESS"],"RECORDS":[{"DATA_SOURCE":"CUSTOMERS","FEATURES":[],"RECORD_ID":"10"},{"DATA_SOURCE":"CUSTOMERS","FEATURES":["d","c"],"RECORD_ID":"2"},{"DATA_SOURCE":"WATCHLIST","FEATURES":["b","a"],"RECORD_ID":"10"}],"RELATED_ENTITIES":[{"NAME":"Unknown"},{"ENTITY_ID":9,"NAME":"Jim"},{"ENTITY_ID":10,"NAME":"Jane"}]}`,
+	},
+	{
+		name:     "by-keys-everywhere",
+		jsonText: `[[{"ID": 10}, {"ID": 9.5}, 3, null], {"A": [{"ID": "b"}, {"ID": "a"}, {"ID": null}, {"ID": true}, {"ID": false}]}]`,
+		options:  jsonutil.SortOptions{ByKeys: []string{"ID"}, Paths: nil},
+		expected: `[[null,3,{"ID":9.5},{"ID":10}],{"A":[{"ID":null},{"ID":false},{"ID":true},{"ID":"a"},{"ID":"b"}]}]`,
+	},
+	{
+		name:     "by-keys-ties",
+		jsonText: `[{"ID": 1, "V": "b"}, {"ID": 1.0, "V": "a"}, {"ID": [2]}, {"ID": [1]}, {"ID": {"x": 1}}]`,
+		options:  jsonutil.SortOptions{ByKeys: []string{"ID"}, Paths: []string{"$"}},
+		expected: `[{"ID":1,"V":"b"},{"ID":1.0,"V":"a"},{"ID":[1]},{"ID":[2]},{"ID":{"x":1}}]`,
+	},
+	{
+		name:     "path-not-an-array",
+		jsonText: `{"a": {"c": 1, "b": 2}}`,
+		options:  jsonutil.SortOptions{ByKeys: nil, Paths: []string{"$.a", "$.b"}},
+		expected: `{"a":{"b":2,"c":1}}`,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestNormalizeAndSortWithOptions(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForNormalizeAndSortWithOptions {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.NormalizeAndSortWithOptions(testCase.jsonText, testCase.options)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestNormalizeAndSortWithOptions_badJSON(test *testing.T) {
+	test.Parallel()
+
+	for _, paths := range [][]string{nil, {"$.a"}} {
+		actual, err := jsonutil.NormalizeAndSortWithOptions(badJSON, jsonutil.SortOptions{ByKeys: nil, Paths: paths})
+		require.Error(test, err)
+		assert.Equal(test, badJSON, actual)
+	}
+}
+
+func TestNormalizeAndSortWithOptions_badSelector(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.NormalizeAndSortWithOptions(`[]`, jsonutil.SortOptions{ByKeys: nil, Paths: []string{"a"}})
+	require.Error(test, err)
+}
+
+func TestNormalizeAndSortWithOptions_matchesNormalizeAndSort(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForNormalizeAndSort {
+		expected, err := jsonutil.NormalizeAndSort(testCase.jsonText)
+		require.NoError(test, err)
+
+		actual, err := jsonutil.NormalizeAndSortWithOptions(testCase.jsonText, jsonutil.SortOptions{ByKeys: nil, Paths: nil})
+		require.NoError(test, err)
+		assert.Equal(test, expected, actual)
+	}
+}
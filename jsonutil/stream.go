@@ -0,0 +1,334 @@
+package jsonutil
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+const (
+	initialLineBufferSize = 64 * 1024
+	jobsPerWorker         = 2
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A streamJob is one line of input.  The result channel is buffered so workers never block on it.
+type streamJob struct {
+	lineNumber int
+	line       string
+	result     chan streamResult
+}
+
+type streamResult struct {
+	jsonText string
+	err      error
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NormalizeAndSortLines function runs [NormalizeAndSort] on each line of JSON lines.
+See [TransformLines].
+
+Input
+  - ctx: A context to control lifecycle.
+  - reader: The source of JSON lines.
+  - writer: The destination of the normalized and sorted JSON lines.
+  - options: Controls line size and parallelism.
+*/
+func NormalizeAndSortLines(ctx context.Context, reader io.Reader, writer io.Writer, options StreamOptions) error {
+	return wraperror.Errorf(TransformLines(ctx, reader, writer, NormalizeAndSort, options), wraperror.NoMessage)
+}
+
+/*
+The NormalizeLines function runs [Normalize] on each line of JSON lines.
+See [TransformLines].
+
+Input
+  - ctx: A context to control lifecycle.
+  - reader: The source of JSON lines.
+  - writer: The destination of the normalized JSON lines.
+  - options: Controls line size and parallelism.
+*/
+func NormalizeLines(ctx context.Context, reader io.Reader, writer io.Writer, options StreamOptions) error {
+	return wraperror.Errorf(TransformLines(ctx, reader, writer, Normalize, options), wraperror.NoMessage)
+}
+
+/*
+The RedactLines function runs [Redact] on each line of JSON lines.
+See [TransformLines].
+
+Input
+  - ctx: A context to control lifecycle.
+  - reader: The source of JSON lines.
+  - writer: The destination of the redacted JSON lines.
+  - options: Controls line size and parallelism.
+  - redactKeys: The JSON key/vaue pair to be redacted.
+*/
+func RedactLines(
+	ctx context.Context,
+	reader io.Reader,
+	writer io.Writer,
+	options StreamOptions,
+	redactKeys ...string,
+) error {
+	transform := func(jsonText string) (string, error) {
+		return Redact(jsonText, redactKeys...)
+	}
+
+	return wraperror.Errorf(TransformLines(ctx, reader, writer, transform, options), wraperror.NoMessage)
+}
+
+/*
+The RedactWithMapLines function runs [RedactWithMap] on each line of JSON lines.
+See [TransformLines].
+
+Input
+  - ctx: A context to control lifecycle.
+  - reader: The source of JSON lines.
+  - writer: The destination of the redacted JSON lines.
+  - options: Controls line size and parallelism.
+  - redactMap: The map of JSON key/value pairs to be redacted to values to be used for redaction.
+*/
+func RedactWithMapLines(
+	ctx context.Context,
+	reader io.Reader,
+	writer io.Writer,
+	options StreamOptions,
+	redactMap map[string]any,
+) error {
+	transform := func(jsonText string) (string, error) {
+		return RedactWithMap(jsonText, redactMap)
+	}
+
+	return wraperror.Errorf(TransformLines(ctx, reader, writer, transform, options), wraperror.NoMessage)
+}
+
+/*
+The StripLines function runs [Strip] on each line of JSON lines.
+See [TransformLines].
+
+Input
+  - ctx: A context to control lifecycle.
+  - reader: The source of JSON lines.
+  - writer: The destination of the modified JSON lines.
+  - options: Controls line size and parallelism.
+  - removeKeys: The JSON keys to be removed.
+*/
+func StripLines(
+	ctx context.Context,
+	reader io.Reader,
+	writer io.Writer,
+	options StreamOptions,
+	removeKeys ...string,
+) error {
+	transform := func(jsonText string) (string, error) {
+		return Strip(jsonText, removeKeys...)
+	}
+
+	return wraperror.Errorf(TransformLines(ctx, reader, writer, transform, options), wraperror.NoMessage)
+}
+
+/*
+The TransformLines function reads JSON lines (one JSON value per line) from the reader,
+applies the transform to each line, and writes the results, one per line, to the writer.
+Blank lines are skipped.
+
+Memory use is bounded: no more than a few lines per worker are held at any time,
+regardless of the size of the input.
+When more than one worker is requested, lines are transformed in parallel,
+but results are always written in the order the lines were read.
+
+Processing stops at the first error.
+Results for the lines preceding the failing line have already been written.
+
+Input
+  - ctx: A context to control lifecycle.
+  - reader: The source of JSON lines.
+  - writer: The destination of the transformed JSON lines.
+  - transform: The function applied to each line (e.g. [Normalize]). It must be safe for concurrent use.
+  - options: Controls line size and parallelism.
+
+Output
+  - An error identifying the line number, if a line could not be read or transformed.
+*/
+func TransformLines(
+	ctx context.Context,
+	reader io.Reader,
+	writer io.Writer,
+	transform Transform,
+	options StreamOptions,
+) error {
+	var (
+		err       error
+		scanErr   error
+		waitGroup sync.WaitGroup
+	)
+
+	workers := max(options.Workers, 1)
+
+	maxLineSize := options.MaxLineSize
+	if maxLineSize <= 0 {
+		maxLineSize = DefaultMaxLineSize
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// "jobs" feeds the workers. "ordered" carries the same jobs, in input order, to the writer below.
+	// The capacity of "ordered" bounds the number of lines in flight.
+
+	jobs := make(chan *streamJob, workers)
+	ordered := make(chan *streamJob, workers*jobsPerWorker)
+
+	for range workers {
+		waitGroup.Go(func() {
+			for job := range jobs {
+				jsonText, err := transform(job.line)
+				job.result <- streamResult{jsonText: jsonText, err: err}
+			}
+		})
+	}
+
+	go func() {
+		defer close(ordered)
+		defer close(jobs)
+
+		scanErr = readLines(ctx, reader, maxLineSize, jobs, ordered)
+	}()
+
+	bufferedWriter := bufio.NewWriter(writer)
+
+	for job := range ordered {
+		result := <-job.result
+		if err != nil {
+			continue // Drain remaining jobs after a failure.
+		}
+
+		switch {
+		case result.err != nil:
+			err = wraperror.Errorf(result.err, "line %d", job.lineNumber)
+		default:
+			err = writeLine(bufferedWriter, result.jsonText)
+		}
+
+		if err != nil {
+			cancel()
+		}
+	}
+
+	waitGroup.Wait()
+
+	flushErr := bufferedWriter.Flush()
+
+	switch {
+	case err != nil:
+		return err
+	case scanErr != nil:
+		return scanErr
+	case flushErr != nil:
+		return wraperror.Errorf(flushErr, "Flush")
+	}
+
+	return nil
+}
+
+/*
+The TruncateLines function runs [Truncate] on each line of JSON lines.
+See [TransformLines].
+
+Input
+  - ctx: A context to control lifecycle.
+  - reader: The source of JSON lines.
+  - writer: The destination of the truncated lines.
+  - options: Controls line size and parallelism.
+  - lines: The number of pretty-printed JSON lines to concatenate.
+  - removeKeys: The JSON keys to be removed.
+*/
+func TruncateLines(
+	ctx context.Context,
+	reader io.Reader,
+	writer io.Writer,
+	options StreamOptions,
+	lines int,
+	removeKeys ...string,
+) error {
+	transform := func(jsonText string) (string, error) {
+		return Truncate(jsonText, lines, removeKeys...), nil
+	}
+
+	return wraperror.Errorf(TransformLines(ctx, reader, writer, transform, options), wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Send each non-blank line to both the writer (via "ordered") and the workers (via "jobs").
+A job is queued for the writer first so that the writer always waits on the oldest line.
+*/
+func readLines(
+	ctx context.Context,
+	reader io.Reader,
+	maxLineSize int,
+	jobs chan<- *streamJob,
+	ordered chan<- *streamJob,
+) error {
+	lineNumber := 0
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, min(initialLineBufferSize, maxLineSize)), maxLineSize)
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		job := &streamJob{
+			lineNumber: lineNumber,
+			line:       line,
+			result:     make(chan streamResult, 1),
+		}
+
+		select {
+		case ordered <- job:
+		case <-ctx.Done():
+			return wraperror.Errorf(ctx.Err(), "ctx.Err")
+		}
+
+		jobs <- job
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return wraperror.Errorf(err, "line %d", lineNumber+1)
+	}
+
+	return wraperror.Errorf(ctx.Err(), "ctx.Err")
+}
+
+func writeLine(writer *bufio.Writer, jsonText string) error {
+	_, err := writer.WriteString(jsonText)
+	if err != nil {
+		return wraperror.Errorf(err, "WriteString")
+	}
+
+	err = writer.WriteByte('\n')
+	if err != nil {
+		return wraperror.Errorf(err, "WriteByte")
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package jsonutil_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonLinesForStream = `{"b": 2, "a": 1}
+
+[3, 1, 2]
+{"SSN": "111-22-3333", "NAME": "Joe", "nested": {"SSN": "444-55-6666"}}
+`
+
+var testCasesForStream = []struct {
+	name     string
+	function func(ctx context.Context, input string, output *strings.Builder, options jsonutil.StreamOptions) error
+	expected string
+}{
+	{
+		name: "NormalizeLines",
+		function: func(ctx context.Context, input string, output *strings.Builder, options jsonutil.StreamOptions) error {
+			return jsonutil.NormalizeLines(ctx, strings.NewReader(input), output, options)
+		},
+		expected: `{"a":1,"b":2}
+[3,1,2]
+{"NAME":"Joe","SSN":"111-22-3333","nested":{"SSN":"444-55-6666"}}
+`,
+	},
+	{
+		name: "NormalizeAndSortLines",
+		function: func(ctx context.Context, input string, output *strings.Builder, options jsonutil.StreamOptions) error {
+			return jsonutil.NormalizeAndSortLines(ctx, strings.NewReader(input), output, options)
+		},
+		expected: `{"a":1,"b":2}
+[1,2,3]
+{"NAME":"Joe","SSN":"111-22-3333","nested":{"SSN":"444-55-6666"}}
+`,
+	},
+	{
+		name: "RedactLines",
+		function: func(ctx context.Context, input string, output *strings.Builder, options jsonutil.StreamOptions) error {
+			return jsonutil.RedactLines(ctx, strings.NewReader(input), output, options, "SSN")
+		},
+		expected: `{"a":1,"b":2}
+[3,1,2]
+{"NAME":"Joe","SSN":null,"nested":{"SSN":null}}
+`,
+	},
+	{
+		name: "RedactWithMapLines",
+		function: func(ctx context.Context, input string, output *strings.Builder, options jsonutil.StreamOptions) error {
+			return jsonutil.RedactWithMapLines(
+				ctx,
+				strings.NewReader(input),
+				output,
+				options,
+				map[string]any{"SSN": "***-**-****"},
+			)
+		},
+		expected: `{"a":1,"b":2}
+[3,1,2]
+{"NAME":"Joe","SSN":"***-**-****","nested":{"SSN":"***-**-****"}}
+`,
+	},
+	{
+		name: "StripLines",
+		function: func(ctx context.Context, input string, output *strings.Builder, options jsonutil.StreamOptions) error {
+			return jsonutil.StripLines(ctx, strings.NewReader(input), output, options, "SSN", "a")
+		},
+		expected: `{"b":2}
+[3,1,2]
+{"NAME":"Joe","nested":{}}
+`,
+	},
+	{
+		name: "TruncateLines",
+		function: func(ctx context.Context, input string, output *strings.Builder, options jsonutil.StreamOptions) error {
+			return jsonutil.TruncateLines(ctx, strings.NewReader(input), output, options, 2, "SSN")
+		},
+		expected: `{"a":1,...
+[1,...
+{"NAME":"Joe",...
+`,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestStreamFunctions(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForStream {
+		for _, workers := range []int{0, 1, 4} {
+			test.Run(fmt.Sprintf("%s-%d", testCase.name, workers), func(test *testing.T) {
+				test.Parallel()
+
+				var output strings.Builder
+
+				err := testCase.function(test.Context(), jsonLinesForStream, &output, jsonutil.StreamOptions{
+					MaxLineSize: 0,
+					Workers:     workers,
+				})
+				require.NoError(test, err)
+				assert.Equal(test, testCase.expected, output.String())
+			})
+		}
+	}
+}
+
+func TestTransformLines_badJSON(test *testing.T) {
+	test.Parallel()
+
+	var output strings.Builder
+
+	input := "{\"a\": 1}\n{\"b\": 2}\n" + badJSON + "\n{\"c\": 3}\n"
+
+	err := jsonutil.NormalizeLines(test.Context(), strings.NewReader(input), &output, jsonutil.StreamOptions{
+		MaxLineSize: 0,
+		Workers:     4,
+	})
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), "line 3")
+	assert.Equal(test, "{\"a\":1}\n{\"b\":2}\n", output.String())
+}
+
+func TestTransformLines_canceledContext(test *testing.T) {
+	test.Parallel()
+
+	var output strings.Builder
+
+	ctx, cancel := context.WithCancel(test.Context())
+	cancel()
+
+	err := jsonutil.NormalizeLines(ctx, strings.NewReader(jsonLinesForStream), &output, jsonutil.StreamOptions{
+		MaxLineSize: 0,
+		Workers:     2,
+	})
+	require.ErrorIs(test, err, context.Canceled)
+}
+
+func TestTransformLines_lineTooLong(test *testing.T) {
+	test.Parallel()
+
+	var output strings.Builder
+
+	input := "{\"a\": 1}\n{\"b\": \"" + strings.Repeat("x", 100) + "\"}\n"
+
+	err := jsonutil.NormalizeLines(test.Context(), strings.NewReader(input), &output, jsonutil.StreamOptions{
+		MaxLineSize: 50,
+		Workers:     1,
+	})
+	require.ErrorIs(test, err, bufio.ErrTooLong)
+	assert.Contains(test, err.Error(), "line 2")
+	assert.Equal(test, "{\"a\":1}\n", output.String())
+}
+
+func TestTransformLines_orderIsStable(test *testing.T) {
+	test.Parallel()
+
+	var (
+		input  strings.Builder
+		output strings.Builder
+	)
+
+	lineCount := 500
+	for lineNumber := range lineCount {
+		fmt.Fprintf(&input, "{\"LINE\": %d}\n", lineNumber)
+	}
+
+	// Later lines finish first, so without reordering the output would be reversed.
+
+	transform := func(jsonText string) (string, error) {
+		var line int
+
+		_, err := fmt.Sscanf(jsonText, "{\"LINE\": %d}", &line)
+		if err != nil {
+			return "", err
+		}
+
+		time.Sleep(time.Duration(lineCount-line) * time.Microsecond)
+
+		return jsonutil.Normalize(jsonText)
+	}
+
+	err := jsonutil.TransformLines(test.Context(), strings.NewReader(input.String()), &output, transform,
+		jsonutil.StreamOptions{
+			MaxLineSize: 0,
+			Workers:     8,
+		})
+	require.NoError(test, err)
+
+	scanner := bufio.NewScanner(strings.NewReader(output.String()))
+	for lineNumber := range lineCount {
+		require.True(test, scanner.Scan())
+		assert.Equal(test, fmt.Sprintf("{\"LINE\":%d}", lineNumber), scanner.Text())
+	}
+
+	assert.False(test, scanner.Scan())
+}
+
+func TestTransformLines_writeError(test *testing.T) {
+	test.Parallel()
+
+	err := jsonutil.NormalizeLines(test.Context(), strings.NewReader(jsonLinesForStream), &failingWriter{},
+		jsonutil.StreamOptions{
+			MaxLineSize: 0,
+			Workers:     2,
+		})
+	require.ErrorIs(test, err, errFailed)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers
+// ----------------------------------------------------------------------------
+
+type failingWriter struct{}
+
+func (writer *failingWriter) Write(contents []byte) (int, error) {
+	_ = contents
+
+	return 0, errFailed
+}
@@ -0,0 +1,107 @@
+package jsonutil
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Most characters shown on each side of the error in SyntaxError.Excerpt.
+const excerptRadius = 40
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Error method describes a SyntaxError, with its position. The excerpt is not included.
+
+Output
+  - A description like "line 3, column 5 (offset 40): invalid character '}' after array element".
+*/
+func (syntaxError *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d (offset %d): %s", syntaxError.Line, syntaxError.Column,
+		syntaxError.Offset, syntaxError.Message)
+}
+
+/*
+The Unwrap method returns the error from encoding/json.
+
+Output
+  - The error from encoding/json (usually a *json.SyntaxError), or nil if the error was found
+    without encoding/json (e.g. by NormalizeLenient).
+*/
+func (syntaxError *SyntaxError) Unwrap() error {
+	return syntaxError.err
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+/*
+Show the line containing the offset, shortened to excerptRadius characters on each side of it,
+and a caret under the offset on the following line. Control characters (e.g. tabs) are shown as spaces,
+so that the caret lines up.
+*/
+func excerpt(text string, offset int) string {
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+
+	lineEnd := strings.IndexByte(text[offset:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(text)
+	} else {
+		lineEnd += offset
+	}
+
+	toSpace := func(character rune) rune {
+		if unicode.IsControl(character) {
+			return ' '
+		}
+
+		return character
+	}
+
+	before := []rune(strings.Map(toSpace, text[lineStart:offset]))
+	after := []rune(strings.Map(toSpace, text[offset:lineEnd]))
+
+	if len(before) > excerptRadius {
+		before = append([]rune("…"), before[len(before)-excerptRadius:]...)
+	}
+
+	if len(after) > excerptRadius {
+		after = append(after[:excerptRadius], []rune("…")...)
+	}
+
+	return strings.TrimRight(string(before)+string(after), " ") + "\n" +
+		strings.Repeat(" ", utf8.RuneCountInString(string(before))) + "^"
+}
+
+/*
+Locate the error from decoding text that is not JSON, returning a *SyntaxError that wraps it.
+If the error cannot be located, it is returned as is.
+*/
+func newSyntaxError(jsonText string, err error) error {
+	for _, issue := range ValidateStrict(jsonText, ValidateOptions{MaxBytes: 0, MaxDepth: 0}) {
+		if issue.Kind == ValidationSyntax || issue.Kind == ValidationTooDeep {
+			return syntaxErrorAt(jsonText, issue.Offset, issue.Message, err)
+		}
+	}
+
+	return err
+}
+
+// Make a *SyntaxError for the error at a byte offset of text.
+func syntaxErrorAt(text string, offset int, message string, err error) *SyntaxError {
+	line, column := textPosition(text, lineStarts(text), offset)
+
+	return &SyntaxError{
+		Column:  column,
+		Excerpt: excerpt(text, offset),
+		Line:    line,
+		Message: message,
+		Offset:  offset,
+		err:     err,
+	}
+}
@@ -0,0 +1,115 @@
+package jsonutil_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/senzing-garage/go-helpers/testfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCasesForSyntaxError = []struct {
+	name     string
+	jsonText string
+	expected jsonutil.SyntaxError
+}{
+	{
+		name:     "one-line",
+		jsonText: `{"A": [1, 2}`,
+		expected: jsonutil.SyntaxError{
+			Column:  12,
+			Excerpt: "{\"A\": [1, 2}\n           ^",
+			Line:    1,
+			Message: "invalid character '}' after array element",
+			Offset:  11,
+		},
+	},
+	{
+		name:     "multiline",
+		jsonText: "{\n\t\"NAME\": \"Émile\",\n\t\"AGE\": 4x\n}",
+		expected: jsonutil.SyntaxError{
+			Column:  10,
+			Excerpt: " \"AGE\": 4x\n         ^",
+			Line:    3,
+			Message: "invalid character 'x' after object key:value pair",
+			Offset:  30,
+		},
+	},
+	{
+		name:     "end-of-input",
+		jsonText: `{"A": [1, 2`,
+		expected: jsonutil.SyntaxError{
+			Column:  12,
+			Excerpt: "{\"A\": [1, 2\n           ^",
+			Line:    1,
+			Message: "unexpected end of JSON input",
+			Offset:  11,
+		},
+	},
+	{
+		name:     "long-line",
+		jsonText: strings.TrimSuffix(testfixtures.FixtureRecords["65536-periods"].JSON, "}") + "]",
+		expected: jsonutil.SyntaxError{
+			Column:  65627,
+			Excerpt: "…" + strings.Repeat(".", 39) + "\"]\n" + strings.Repeat(" ", 41) + "^",
+			Line:    1,
+			Message: "invalid character ']' after object key:value pair",
+			Offset:  65626,
+		},
+	},
+	{
+		name:     "too-deep",
+		jsonText: strings.Repeat("[", jsonutil.DefaultMaxDepth+1),
+		expected: jsonutil.SyntaxError{
+			Column:  jsonutil.DefaultMaxDepth + 1,
+			Excerpt: "…" + strings.Repeat("[", 41) + "\n" + strings.Repeat(" ", 41) + "^",
+			Line:    1,
+			Message: "arrays and objects nested more than 10000 deep",
+			Offset:  jsonutil.DefaultMaxDepth,
+		},
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface methods
+// ----------------------------------------------------------------------------
+
+func TestSyntaxError(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForSyntaxError {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			var syntaxError *jsonutil.SyntaxError
+
+			_, err := jsonutil.Normalize(testCase.jsonText)
+			require.ErrorAs(test, err, &syntaxError)
+			assert.Equal(test, testCase.expected.Column, syntaxError.Column)
+			assert.Equal(test, testCase.expected.Excerpt, syntaxError.Excerpt)
+			assert.Equal(test, testCase.expected.Line, syntaxError.Line)
+			assert.Equal(test, testCase.expected.Message, syntaxError.Message)
+			assert.Equal(test, testCase.expected.Offset, syntaxError.Offset)
+		})
+	}
+}
+
+func TestSyntaxError_Error(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.Redact(`{"A": [1, 2}`, "A")
+	require.ErrorContains(test, err, "line 1, column 12 (offset 11): invalid character '}' after array element")
+}
+
+func TestSyntaxError_Unwrap(test *testing.T) {
+	test.Parallel()
+
+	var jsonSyntaxError *json.SyntaxError
+
+	_, err := jsonutil.Normalize(`{"A": [1, 2}`)
+	require.ErrorAs(test, err, &jsonSyntaxError)
+	assert.Equal(test, "invalid character '}' after array element", jsonSyntaxError.Error())
+}
@@ -0,0 +1,422 @@
+package jsonutil
+
+import (
+	"cmp"
+	"encoding/json"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// Separates the keys of a TOML key path in the order index of TOMLToJSON. TOML keys may contain ".", but not NUL.
+const tomlKeySeparator = "\x00"
+
+var bareTOMLKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A tomlWriter writes a JSON object as TOML tables.
+type tomlWriter struct {
+	builder strings.Builder
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The JSONToTOML function converts a JSON object to a TOML document (e.g. to maintain Senzing engine settings in TOML).
+Members are kept in their original order where TOML allows: in each table, values come first,
+then tables (e.g. [PIPELINE]), then arrays of tables (e.g. [[RECORDS]]).
+Empty objects, and objects in arrays that also hold other values, are written as inline tables.
+
+Input
+  - jsonText: The JSON object to be converted.
+
+Output
+  - The TOML document, ending with a newline unless the object is empty.
+  - An error if the text is not a JSON object, or has values that TOML cannot represent
+    (null, and integers outside the range of int64).
+*/
+func JSONToTOML(jsonText string) (string, error) {
+	node, err := parseOrderedObject(jsonText)
+	if err != nil {
+		return jsonText, err
+	}
+
+	writer := &tomlWriter{
+		builder: strings.Builder{},
+	}
+
+	err = writer.table(node, "", "$", false)
+	if err != nil {
+		return jsonText, err
+	}
+
+	return writer.builder.String(), nil
+}
+
+/*
+The TOMLToJSON function converts a TOML document to a JSON object, compact as by [Normalize],
+but with members in the order they appear in the TOML document. To sort the members, use [Normalize] on the result.
+
+Dates and times become strings as written in TOML (e.g. "1979-05-27" and "1979-05-27T07:32:00Z").
+Integers are written in decimal, and floats as by [json.Marshal] (e.g. 0x1F and 1e3 become 31 and 1000).
+
+Input
+  - tomlText: The TOML document to be converted.
+
+Output
+  - The JSON object, compact.
+  - An error if the text is not TOML, or has values that JSON cannot represent (e.g. inf and nan).
+*/
+func TOMLToJSON(tomlText string) (string, error) {
+	var document map[string]any
+
+	metadata, err := toml.Decode(tomlText, &document)
+	if err != nil {
+		return tomlText, wraperror.Errorf(err, "Decode")
+	}
+
+	order := tomlKeyOrder(metadata)
+
+	node, err := jsonNodeFromTOML(document, "", "$", order)
+	if err != nil {
+		return tomlText, err
+	}
+
+	var builder strings.Builder
+
+	writeCompact(&builder, node)
+
+	return builder.String(), nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Write the members of an object, at the selector path, as a TOML table. The header is empty for the document itself.
+func (writer *tomlWriter) table(node formatNode, header string, path string, isArrayElement bool) error {
+	var tables, arraysOfTables []int
+
+	inlineCount := 0
+
+	for index, child := range node.children {
+		switch {
+		case child.kind == '{' && len(child.children) > 0:
+			tables = append(tables, index)
+		case isArrayOfTables(child):
+			arraysOfTables = append(arraysOfTables, index)
+		default:
+			inlineCount++
+		}
+	}
+
+	// A table holding only other tables needs no header of its own.
+
+	if header != "" && (isArrayElement || inlineCount > 0) {
+		if writer.builder.Len() > 0 {
+			writer.builder.WriteByte('\n')
+		}
+
+		if isArrayElement {
+			writer.builder.WriteString("[[" + header + "]]\n")
+		} else {
+			writer.builder.WriteString("[" + header + "]\n")
+		}
+	}
+
+	for index, child := range node.children {
+		if slices.Contains(tables, index) || slices.Contains(arraysOfTables, index) {
+			continue
+		}
+
+		value, err := tomlInlineValue(child, appendKey(path, node.keys[index]))
+		if err != nil {
+			return err
+		}
+
+		writer.builder.WriteString(tomlKey(node.keys[index]) + " = " + value + "\n")
+	}
+
+	for _, index := range tables {
+		err := writer.table(node.children[index], joinTOMLKey(header, node.keys[index]),
+			appendKey(path, node.keys[index]), false)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, index := range arraysOfTables {
+		for position, element := range node.children[index].children {
+			err := writer.table(element, joinTOMLKey(header, node.keys[index]),
+				appendIndex(appendKey(path, node.keys[index]), position), true)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Report whether a JSON value is written as a TOML array of tables: a non-empty array of only objects.
+func isArrayOfTables(node formatNode) bool {
+	if node.kind != '[' || len(node.children) == 0 {
+		return false
+	}
+
+	for _, child := range node.children {
+		if child.kind != '{' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Convert a value decoded from TOML, at the key path and selector path, to JSON.
+func jsonNodeFromTOML(value any, keyPath string, path string, order map[string]int) (formatNode, error) {
+	result := formatNode{
+		children: nil,
+		keys:     nil,
+		kind:     0,
+		scalar:   "",
+	}
+
+	switch typedValue := value.(type) {
+	case map[string]any:
+		result.kind = '{'
+		result.keys = sortedTOMLKeys(typedValue, keyPath, order)
+
+		for _, key := range result.keys {
+			child, err := jsonNodeFromTOML(typedValue[key], joinTOMLKeyPath(keyPath, key), appendKey(path, key), order)
+			if err != nil {
+				return result, err
+			}
+
+			result.children = append(result.children, child)
+		}
+	case []map[string]any:
+		result.kind = '['
+
+		for index, element := range typedValue {
+			child, err := jsonNodeFromTOML(element, tomlElementKeyPath(keyPath, index), appendIndex(path, index), order)
+			if err != nil {
+				return result, err
+			}
+
+			result.children = append(result.children, child)
+		}
+	case []any:
+		result.kind = '['
+
+		for index, element := range typedValue {
+			child, err := jsonNodeFromTOML(element, keyPath, appendIndex(path, index), order)
+			if err != nil {
+				return result, err
+			}
+
+			result.children = append(result.children, child)
+		}
+	case string:
+		result.scalar = encodeString(typedValue)
+	case bool:
+		result.scalar = strconv.FormatBool(typedValue)
+	case int64:
+		result.scalar = strconv.FormatInt(typedValue, decimalBase)
+	case float64:
+		if math.IsInf(typedValue, 0) || math.IsNaN(typedValue) {
+			return result, wraperror.Errorf(errForPackage, "at %s, %v cannot be written in JSON", path, typedValue)
+		}
+
+		number, err := json.Marshal(typedValue)
+		result.scalar = string(number)
+
+		return result, wraperror.Errorf(err, "Marshal")
+	case time.Time:
+		result.scalar = encodeString(tomlTimeText(typedValue))
+	default:
+		return result, wraperror.Errorf(errForPackage, "at %s, unexpected TOML value %T", path, value)
+	}
+
+	return result, nil
+}
+
+// Add a key to a TOML table header (e.g. PIPELINE to SETTINGS makes SETTINGS.PIPELINE).
+func joinTOMLKey(header string, key string) string {
+	if header == "" {
+		return tomlKey(key)
+	}
+
+	return header + "." + tomlKey(key)
+}
+
+// Add a key to a key path in the order index of TOMLToJSON.
+func joinTOMLKeyPath(keyPath string, key string) string {
+	if keyPath == "" {
+		return key
+	}
+
+	return keyPath + tomlKeySeparator + key
+}
+
+// Order the keys of a TOML table as they appear in the document. Keys not found in the order index come last, sorted.
+func sortedTOMLKeys(table map[string]any, keyPath string, order map[string]int) []string {
+	result := make([]string, 0, len(table))
+	for key := range table {
+		result = append(result, key)
+	}
+
+	slices.SortFunc(result, func(key1 string, key2 string) int {
+		position1, isFound1 := order[joinTOMLKeyPath(keyPath, key1)]
+		position2, isFound2 := order[joinTOMLKeyPath(keyPath, key2)]
+
+		switch {
+		case isFound1 && isFound2:
+			return cmp.Compare(position1, position2)
+		case isFound1:
+			return -1
+		case isFound2:
+			return 1
+		default:
+			return strings.Compare(key1, key2)
+		}
+	})
+
+	return result
+}
+
+// Add the index of an element of an array of tables to a key path in the order index of TOMLToJSON.
+func tomlElementKeyPath(keyPath string, index int) string {
+	return keyPath + tomlKeySeparator + "[" + strconv.Itoa(index) + "]"
+}
+
+/*
+Index the position of the first appearance of each key path in a TOML document.
+Each element of an array of tables has its own key paths (see tomlElementKeyPath),
+so that elements may order their keys differently. Elements of inline arrays share key paths.
+*/
+func tomlKeyOrder(metadata toml.MetaData) map[string]int {
+	result := map[string]int{}
+	elementCount := map[string]int{} // Elements of each array of tables, by key path.
+
+	for position, key := range metadata.Keys() {
+		keyPath := ""
+
+		for length := 1; length <= len(key); length++ { // Dotted keys (e.g. a.b.c = 1) also define their prefixes.
+			keyPath = joinTOMLKeyPath(keyPath, key[length-1])
+			if _, isFound := result[keyPath]; !isFound {
+				result[keyPath] = position
+			}
+
+			if metadata.Type(key[:length]...) != "ArrayHash" {
+				continue
+			}
+
+			if length == len(key) { // A [[header]] starts a new element.
+				elementCount[keyPath]++
+			}
+
+			keyPath = tomlElementKeyPath(keyPath, elementCount[keyPath]-1)
+			if _, isFound := result[keyPath]; !isFound {
+				result[keyPath] = position
+			}
+		}
+	}
+
+	return result
+}
+
+// Write a JSON value, at the selector path, as a TOML value on one line.
+func tomlInlineValue(node formatNode, path string) (string, error) {
+	var parts []string
+
+	switch node.kind {
+	case '[':
+		for index, child := range node.children {
+			value, err := tomlInlineValue(child, appendIndex(path, index))
+			if err != nil {
+				return "", err
+			}
+
+			parts = append(parts, value)
+		}
+
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	case '{':
+		if len(node.children) == 0 {
+			return "{}", nil
+		}
+
+		for index, child := range node.children {
+			value, err := tomlInlineValue(child, appendKey(path, node.keys[index]))
+			if err != nil {
+				return "", err
+			}
+
+			parts = append(parts, tomlKey(node.keys[index])+" = "+value)
+		}
+
+		return "{ " + strings.Join(parts, ", ") + " }", nil
+	}
+
+	switch {
+	case node.scalar == Null:
+		return "", wraperror.Errorf(errForPackage, "at %s, TOML has no null", path)
+	case node.scalar[0] == '"':
+		return tomlString(node.scalar), nil
+	case node.scalar == "true" || node.scalar == "false":
+		return node.scalar, nil
+	case strings.ContainsAny(node.scalar, ".eE"):
+		return node.scalar, nil // JSON floats are also TOML floats.
+	}
+
+	_, err := strconv.ParseInt(node.scalar, decimalBase, 64)
+	if err != nil {
+		return "", wraperror.Errorf(errForPackage, "at %s, %s is outside the range of TOML integers", path, node.scalar)
+	}
+
+	return node.scalar, nil
+}
+
+// Write a key as a TOML bare key if it can be, otherwise as a basic string.
+func tomlKey(key string) string {
+	if bareTOMLKeyPattern.MatchString(key) {
+		return key
+	}
+
+	return tomlString(encodeString(key))
+}
+
+// Make a TOML basic string from a JSON string. TOML has JSON's escapes, but also requires DEL to be escaped.
+func tomlString(jsonString string) string {
+	return strings.ReplaceAll(jsonString, "\x7f", `\u007F`)
+}
+
+// Write a TOML date or time as it would appear in TOML. Local dates and times are marked by their time zone.
+func tomlTimeText(value time.Time) string {
+	switch value.Location().String() {
+	case "date-local":
+		return value.Format(time.DateOnly)
+	case "time-local":
+		return value.Format("15:04:05.999999999")
+	case "datetime-local":
+		return value.Format("2006-01-02T15:04:05.999999999")
+	default:
+		return value.Format(time.RFC3339Nano)
+	}
+}
@@ -0,0 +1,264 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The key of the member that marks elided object members.
+const elisionKey = "…"
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+type truncator struct {
+	nodes   int // Values that may still be written, if options.MaxNodes > 0.
+	options TruncateOptions
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The TruncateWithOptions function shortens JSON while keeping it valid JSON, replacing what is left out
+with markers that say how much is missing:
+
+  - Strings longer than options.MaxStringLength characters are cut: "Lorem ipsum…(1234 more characters)".
+  - Arrays longer than options.MaxArrayItems keep their first elements, then "…(123 more items)".
+  - Arrays and objects nested deeper than options.MaxDepth are replaced by "…(array of 12 items)"
+    or "…(object with 3 members)". The outermost array or object is at depth 1.
+  - After options.MaxNodes values, the remaining elements of arrays are replaced by "…(12 more items)"
+    and the remaining members of objects by a "…" member: "…": "(3 more members)".
+  - Output is kept within options.MaxBytes bytes by eliding elements and members as for options.MaxNodes,
+    and cutting strings.
+
+Limits that are zero or negative are not applied.
+Elements and members are kept in order, so only the end of an array or object is elided.
+The output is compact (no whitespace), with object members in their original order.
+
+If the outermost value cannot fit in options.MaxBytes, it is replaced by a single marker string
+(e.g. "…(object with 3 members)"), which may itself be longer than a very small options.MaxBytes.
+
+Input
+  - jsonText: The JSON text to be truncated.
+  - options: The limits.
+
+Output
+  - The truncated JSON text.
+  - An error if the text is not JSON.
+*/
+func TruncateWithOptions(jsonText string, options TruncateOptions) (string, error) {
+	node, err := parseOrdered(jsonText)
+	if err != nil {
+		return jsonText, err
+	}
+
+	budget := options.MaxBytes
+	if budget <= 0 {
+		budget = math.MaxInt
+	}
+
+	jsonTruncator := &truncator{
+		nodes:   max(options.MaxNodes, 1),
+		options: options,
+	}
+
+	result, fits := jsonTruncator.truncate(node, 1, budget)
+	if !fits {
+		result = encodeString(describeNode(node))
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+/*
+Truncate an array or object within budget bytes.
+After each element or member, there is room left for the closing bracket and a marker for the rest.
+*/
+func (jsonTruncator *truncator) container(node formatNode, depth int, budget int) (string, bool) {
+	count := len(node.children)
+	limit := count
+
+	if node.kind == '[' && jsonTruncator.options.MaxArrayItems > 0 {
+		limit = min(limit, jsonTruncator.options.MaxArrayItems)
+	}
+
+	if len("[]") > budget {
+		return "", false
+	}
+
+	var result strings.Builder
+
+	result.WriteRune(rune(node.kind))
+
+	written := 0
+
+	for index := range limit {
+		memberPrefix := ""
+		if index > 0 {
+			memberPrefix = ","
+		}
+
+		if node.kind == '{' {
+			memberPrefix += encodeString(node.keys[index]) + ":"
+		}
+
+		reserve := len("]")
+		if index+1 < count {
+			reserve += elisionLength(node.kind, count-index-1)
+		}
+
+		childBudget := budget - result.Len() - len(memberPrefix) - reserve
+
+		savedNodes := jsonTruncator.nodes
+
+		childText, fits := jsonTruncator.truncate(node.children[index], depth+1, childBudget)
+		if !fits {
+			jsonTruncator.nodes = savedNodes
+
+			break
+		}
+
+		result.WriteString(memberPrefix)
+		result.WriteString(childText)
+
+		written++
+	}
+
+	if written < count {
+		if written > 0 {
+			result.WriteByte(',')
+		}
+
+		result.WriteString(elision(node.kind, count-written))
+	}
+
+	result.WriteByte(closingDelimiter(node.kind))
+
+	// Only a marker in place of the first element or member can be too long; reserve ensures the others fit.
+
+	return result.String(), result.Len() <= budget
+}
+
+// Cut a string to options.MaxStringLength characters, and then to fit the budget.
+func (jsonTruncator *truncator) string(scalar string, budget int) (string, bool) {
+	var text string
+
+	_ = json.Unmarshal([]byte(scalar), &text) // The scalar was encoded by encodeString.
+
+	characters := []rune(text)
+	maxLength := jsonTruncator.options.MaxStringLength
+
+	if (maxLength <= 0 || len(characters) <= maxLength) && len(scalar) <= budget {
+		return scalar, true
+	}
+
+	cut := func(keep int) string {
+		return encodeString(string(characters[:keep]) + "…(" + strconv.Itoa(len(characters)-keep) + " more characters)")
+	}
+
+	// Find the most characters that fit, by binary search.
+
+	low, high := 0, len(characters)-1
+	if maxLength > 0 {
+		high = min(high, maxLength)
+	}
+
+	if len(cut(low)) > budget {
+		return "", false
+	}
+
+	for low < high {
+		middle := (low + high + 1) / 2 //nolint:mnd
+		if len(cut(middle)) <= budget {
+			low = middle
+		} else {
+			high = middle - 1
+		}
+	}
+
+	return cut(low), true
+}
+
+// Truncate a value within budget bytes. Returns false if it cannot fit at all.
+func (jsonTruncator *truncator) truncate(node formatNode, depth int, budget int) (string, bool) {
+	if jsonTruncator.options.MaxNodes > 0 {
+		if jsonTruncator.nodes <= 0 {
+			return "", false
+		}
+
+		jsonTruncator.nodes--
+	}
+
+	var result string
+
+	switch {
+	case node.kind == 0 && node.scalar[0] == '"':
+		return jsonTruncator.string(node.scalar, budget)
+	case node.kind == 0:
+		result = node.scalar
+	case len(node.children) == 0:
+		result = string(node.kind) + string(closingDelimiter(node.kind))
+	case jsonTruncator.options.MaxDepth > 0 && depth > jsonTruncator.options.MaxDepth:
+		result = encodeString(describeNode(node))
+	default:
+		return jsonTruncator.container(node, depth, budget)
+	}
+
+	return result, len(result) <= budget
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func closingDelimiter(kind json.Delim) byte {
+	if kind == '[' {
+		return ']'
+	}
+
+	return '}'
+}
+
+// A marker that replaces a whole value.
+func describeNode(node formatNode) string {
+	switch node.kind {
+	case '[':
+		return "…(array of " + strconv.Itoa(len(node.children)) + " items)"
+	case '{':
+		return "…(object with " + strconv.Itoa(len(node.children)) + " members)"
+	default:
+		if node.scalar[0] == '"' {
+			var text string
+
+			_ = json.Unmarshal([]byte(node.scalar), &text)
+
+			return "…(string of " + strconv.Itoa(utf8.RuneCountInString(text)) + " characters)"
+		}
+
+		return "…(" + strconv.Itoa(len(node.scalar)) + " characters)"
+	}
+}
+
+// The marker for elided elements of an array or members of an object.
+func elision(kind json.Delim, count int) string {
+	if kind == '[' {
+		return encodeString("…(" + strconv.Itoa(count) + " more items)")
+	}
+
+	return encodeString(elisionKey) + ":" + encodeString("("+strconv.Itoa(count)+" more members)")
+}
+
+// The length of the marker, with a separating comma.
+func elisionLength(kind json.Delim, count int) int {
+	return len(",") + len(elision(kind, count))
+}
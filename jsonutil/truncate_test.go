@@ -0,0 +1,180 @@
+package jsonutil_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonTextForTruncate = `{
+	"RESOLVED_ENTITY": {
+		"ENTITY_ID": 9007199254740993,
+		"ENTITY_NAME": "Robert Smith",
+		"RECORDS": [
+			{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"},
+			{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1002"},
+			{"DATA_SOURCE": "WATCHLIST", "RECORD_ID": "1003"}
+		]
+	},
+	"NOTES": "Lorem ipsum dolor sit amet, consectetur adipiscing elit",
+	"SCORES": [100, 99, 98, 97, 96, 95]
+}`
+
+var testCasesForTruncateWithOptions = []struct {
+	name     string
+	options  jsonutil.TruncateOptions
+	expected string
+}{
+	{
+		name:     "no-limits",
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 0, MaxBytes: 0, MaxDepth: 0, MaxNodes: 0, MaxStringLength: 0},
+		expected: `{"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"ENTITY_NAME":"Robert Smith","RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"},{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1002"},{"DATA_SOURCE":"WATCHLIST","RECORD_ID":"1003"}]},"NOTES":"Lorem ipsum dolor sit amet, consectetur adipiscing elit","SCORES":[100,99,98,97,96,95]}`,
+	},
+	{
+		name:     "max-array-items",
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 2, MaxBytes: 0, MaxDepth: 0, MaxNodes: 0, MaxStringLength: 0},
+		expected: `{"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"ENTITY_NAME":"Robert Smith","RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"},{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1002"},"…(1 more items)"]},"NOTES":"Lorem ipsum dolor sit amet, consectetur adipiscing elit","SCORES":[100,99,"…(4 more items)"]}`,
+	},
+	{
+		name:     "max-depth",
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 0, MaxBytes: 0, MaxDepth: 2, MaxNodes: 0, MaxStringLength: 0},
+		expected: `{"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"ENTITY_NAME":"Robert Smith","RECORDS":"…(array of 3 items)"},"NOTES":"Lorem ipsum dolor sit amet, consectetur adipiscing elit","SCORES":[100,99,98,97,96,95]}`,
+	},
+	{
+		name:     "max-nodes",
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 0, MaxBytes: 0, MaxDepth: 0, MaxNodes: 8, MaxStringLength: 0},
+		expected: `{"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"ENTITY_NAME":"Robert Smith","RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"},"…(2 more items)"]},"…":"(2 more members)"}`,
+	},
+	{
+		name:     "max-string-length",
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 0, MaxBytes: 0, MaxDepth: 0, MaxNodes: 0, MaxStringLength: 11},
+		expected: `{"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"ENTITY_NAME":"Robert Smit…(1 more characters)","RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"},{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1002"},{"DATA_SOURCE":"WATCHLIST","RECORD_ID":"1003"}]},"NOTES":"Lorem ipsum…(44 more characters)","SCORES":[100,99,98,97,96,95]}`,
+	},
+	{
+		name:     "max-bytes",
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 0, MaxBytes: 200, MaxDepth: 0, MaxNodes: 0, MaxStringLength: 0},
+		expected: `{"RESOLVED_ENTITY":{"ENTITY_ID":9007199254740993,"ENTITY_NAME":"Robert Smith","RECORDS":[{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"},"…(2 more items)"]},"…":"(2 more members)"}`,
+	},
+	{
+		name:     "max-bytes-too-small",
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 0, MaxBytes: 5, MaxDepth: 0, MaxNodes: 0, MaxStringLength: 0},
+		expected: `"…(object with 3 members)"`,
+	},
+}
+
+var testCasesForTruncateWithOptionsScalars = []struct {
+	name     string
+	jsonText string
+	options  jsonutil.TruncateOptions
+	expected string
+}{
+	{
+		name:     "string-to-fit",
+		jsonText: `"Lorem ipsum dolor sit amet, consectetur adipiscing elit"`,
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 0, MaxBytes: 40, MaxDepth: 0, MaxNodes: 0, MaxStringLength: 0},
+		expected: `"Lorem ipsum dol…(40 more characters)"`,
+	},
+	{
+		name:     "escaped-string",
+		jsonText: `"\"\"\"\"\"\"\"\"\"\"abcdefghij"`,
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 0, MaxBytes: 30, MaxDepth: 0, MaxNodes: 0, MaxStringLength: 0},
+		expected: `"\"\"…(18 more characters)"`,
+	},
+	{
+		name:     "multibyte-string",
+		jsonText: `["ééééé"]`,
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 0, MaxBytes: 0, MaxDepth: 0, MaxNodes: 0, MaxStringLength: 2},
+		expected: `["éé…(3 more characters)"]`,
+	},
+	{
+		name:     "number",
+		jsonText: `12345678901234567890`,
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 0, MaxBytes: 10, MaxDepth: 0, MaxNodes: 0, MaxStringLength: 0},
+		expected: `"…(20 characters)"`,
+	},
+	{
+		name:     "empty-containers",
+		jsonText: `[[], {}]`,
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 0, MaxBytes: 0, MaxDepth: 1, MaxNodes: 0, MaxStringLength: 0},
+		expected: `[[],{}]`,
+	},
+	{
+		name:     "fits-exactly",
+		jsonText: `[1]`,
+		options:  jsonutil.TruncateOptions{MaxArrayItems: 0, MaxBytes: 3, MaxDepth: 0, MaxNodes: 0, MaxStringLength: 0},
+		expected: `[1]`,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestTruncateWithOptions(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForTruncateWithOptions {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.TruncateWithOptions(jsonTextForTruncate, testCase.options)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+			assert.True(test, json.Valid([]byte(actual)))
+		})
+	}
+}
+
+func TestTruncateWithOptions_badJSON(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.TruncateWithOptions(badJSON, jsonutil.TruncateOptions{
+		MaxArrayItems:   0,
+		MaxBytes:        10,
+		MaxDepth:        0,
+		MaxNodes:        0,
+		MaxStringLength: 0,
+	})
+	require.Error(test, err)
+	assert.Equal(test, badJSON, actual)
+}
+
+func TestTruncateWithOptions_everyMaxBytes(test *testing.T) {
+	test.Parallel()
+
+	for maxBytes := 30; maxBytes <= len(jsonTextForTruncate); maxBytes++ {
+		test.Run(strconv.Itoa(maxBytes), func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.TruncateWithOptions(jsonTextForTruncate, jsonutil.TruncateOptions{
+				MaxArrayItems:   0,
+				MaxBytes:        maxBytes,
+				MaxDepth:        0,
+				MaxNodes:        0,
+				MaxStringLength: 0,
+			})
+			require.NoError(test, err)
+			assert.LessOrEqual(test, len(actual), maxBytes)
+			assert.True(test, json.Valid([]byte(actual)), actual)
+		})
+	}
+}
+
+func TestTruncateWithOptions_scalars(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForTruncateWithOptionsScalars {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.TruncateWithOptions(testCase.jsonText, testCase.options)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+			assert.True(test, json.Valid([]byte(actual)))
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The MarshalNormalized function marshals a value (e.g. a struct for a Senzing record) to JSON,
+normalized as by [Normalize]: compact, with object members sorted by key.
+
+Input
+  - value: The value to be marshalled.
+
+Output
+  - The normalized JSON text.
+  - An error if the value cannot be marshalled (e.g. a channel, or a float that is NaN).
+*/
+func MarshalNormalized[T any](value T) (string, error) {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return "", wraperror.Errorf(err, "Marshal")
+	}
+
+	result, err := Normalize(string(jsonBytes))
+
+	return result, wraperror.Errorf(err, "Normalize")
+}
+
+/*
+The MustMarshalNormalized function is like [MarshalNormalized], but panics if the value cannot be marshalled.
+It is meant for values known to be JSON, as in tests and package-level variables.
+
+Input
+  - value: The value to be marshalled.
+
+Output
+  - The normalized JSON text.
+*/
+func MustMarshalNormalized[T any](value T) string {
+	result, err := MarshalNormalized(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+/*
+The ParseAs function parses JSON text into a value of type T (e.g. a struct for Senzing entity JSON).
+As for [Normalize], the text must hold exactly one JSON value, and numbers held as any (e.g. in a map[string]any)
+are kept as written, as a [json.Number].
+
+Input
+  - jsonText: The JSON text to be parsed.
+
+Output
+  - The value of type T.
+  - An error if the text is not JSON, located by a [SyntaxError],
+    or if it does not fit T (e.g. a string where T has an int).
+*/
+func ParseAs[T any](jsonText string) (T, error) {
+	var result T
+
+	decoder := json.NewDecoder(strings.NewReader(jsonText))
+	decoder.UseNumber()
+
+	decodeErr := decoder.Decode(&result)
+	if decodeErr == nil && len(strings.Trim(jsonText[decoder.InputOffset():], jsonWhitespace)) == 0 {
+		return result, nil
+	}
+
+	var zero T
+
+	// If the text is not JSON, describe and locate the problem. Otherwise it does not fit T.
+
+	_, err := unmarshal(jsonText)
+	if err != nil {
+		return zero, wraperror.Errorf(err, "Unmarshal")
+	}
+
+	return zero, wraperror.Errorf(decodeErr, "Decode")
+}
+
+/*
+The RoundTrip function parses JSON text into a value of type T, and marshals it back to normalized JSON,
+as by [ParseAs] and [MarshalNormalized].
+The result holds only what T keeps: e.g. members that a struct has no field for are dropped,
+so comparing the result with [Normalize] of the text shows whether T covers a document.
+
+Input
+  - jsonText: The JSON text to be converted.
+
+Output
+  - The normalized JSON text of the value of type T.
+  - An error if the text is not JSON, or does not fit T.
+*/
+func RoundTrip[T any](jsonText string) (string, error) {
+	value, err := ParseAs[T](jsonText)
+	if err != nil {
+		return jsonText, err
+	}
+
+	result, err := MarshalNormalized(value)
+	if err != nil {
+		return jsonText, err
+	}
+
+	return result, nil
+}
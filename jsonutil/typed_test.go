@@ -0,0 +1,200 @@
+package jsonutil_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type typedRecord struct {
+	DataSource string      `json:"DATA_SOURCE"`
+	RecordID   string      `json:"RECORD_ID"`
+	Names      []typedName `json:"NAMES,omitempty"`
+}
+
+type typedName struct {
+	NameFirst string `json:"NAME_FIRST,omitempty"`
+	NameLast  string `json:"NAME_LAST"`
+}
+
+var testCasesForParseAsError = []struct {
+	name     string
+	jsonText string
+	expected string
+}{
+	{
+		name:     "not-json",
+		jsonText: `{"DATA_SOURCE": "CUSTOMERS",}`,
+		expected: "line 1, column 29 (offset 28): invalid character '}' looking for beginning of object key string",
+	},
+	{
+		name:     "text-after-value",
+		jsonText: `{"DATA_SOURCE": "CUSTOMERS"} {}`,
+		expected: "line 1, column 30 (offset 29): invalid character '{' after top-level value",
+	},
+	{
+		name:     "wrong-type",
+		jsonText: `{"DATA_SOURCE": "CUSTOMERS", "NAMES": {"NAME_LAST": "Smith"}}`,
+		expected: "cannot unmarshal object into Go struct field typedRecord.NAMES of type []jsonutil_test.typedName",
+	},
+	{
+		name:     "empty",
+		jsonText: "",
+		expected: "unexpected end of JSON input",
+	},
+}
+
+var testCasesForRoundTrip = []struct {
+	name     string
+	jsonText string
+	expected string
+}{
+	{
+		name:     "complete",
+		jsonText: `{"RECORD_ID": "1001", "DATA_SOURCE": "CUSTOMERS", "NAMES": [{"NAME_LAST": "Smith"}]}`,
+		expected: `{"DATA_SOURCE":"CUSTOMERS","NAMES":[{"NAME_LAST":"Smith"}],"RECORD_ID":"1001"}`,
+	},
+	{
+		name:     "unknown-members-dropped",
+		jsonText: `{"RECORD_ID": "1001", "DATA_SOURCE": "CUSTOMERS", "ADDR_FULL": "123 Main St"}`,
+		expected: `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}`,
+	},
+	{
+		name:     "missing-members-added",
+		jsonText: `{"NAMES": [{"NAME_FIRST": "Robert"}]}`,
+		expected: `{"DATA_SOURCE":"","NAMES":[{"NAME_FIRST":"Robert","NAME_LAST":""}],"RECORD_ID":""}`,
+	},
+	{
+		name:     "null",
+		jsonText: `null`,
+		expected: `{"DATA_SOURCE":"","RECORD_ID":""}`,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestMarshalNormalized(test *testing.T) {
+	test.Parallel()
+
+	record := typedRecord{
+		DataSource: "CUSTOMERS",
+		RecordID:   "1001",
+		Names:      []typedName{{NameFirst: "Robert", NameLast: "Smith"}},
+	}
+
+	actual, err := jsonutil.MarshalNormalized(record)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"DATA_SOURCE":"CUSTOMERS","NAMES":[{"NAME_FIRST":"Robert","NAME_LAST":"Smith"}],"RECORD_ID":"1001"}`,
+		actual)
+
+	expected, err := jsonutil.Normalize(actual)
+	require.NoError(test, err)
+	assert.Equal(test, expected, actual)
+}
+
+func TestMarshalNormalized_map(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.MarshalNormalized(map[string]any{"b": []any{2, 1}, "a": json.Number("1.50")})
+	require.NoError(test, err)
+	assert.Equal(test, `{"a":1.50,"b":[2,1]}`, actual)
+}
+
+func TestMarshalNormalized_error(test *testing.T) {
+	test.Parallel()
+
+	_, err := jsonutil.MarshalNormalized(math.NaN())
+	require.ErrorContains(test, err, "unsupported value: NaN")
+}
+
+func TestMustMarshalNormalized(test *testing.T) {
+	test.Parallel()
+
+	actual := jsonutil.MustMarshalNormalized(typedName{NameFirst: "", NameLast: "Smith"})
+	assert.Equal(test, `{"NAME_LAST":"Smith"}`, actual)
+}
+
+func TestMustMarshalNormalized_panic(test *testing.T) {
+	test.Parallel()
+
+	assert.Panics(test, func() {
+		jsonutil.MustMarshalNormalized(make(chan int))
+	})
+}
+
+func TestParseAs(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.ParseAs[typedRecord](
+		` {"RECORD_ID": "1001", "DATA_SOURCE": "CUSTOMERS", "NAMES": [{"NAME_LAST": "Smith"}]} `)
+	require.NoError(test, err)
+
+	expected := typedRecord{
+		DataSource: "CUSTOMERS",
+		RecordID:   "1001",
+		Names:      []typedName{{NameFirst: "", NameLast: "Smith"}},
+	}
+	assert.Equal(test, expected, actual)
+}
+
+func TestParseAs_numbers(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.ParseAs[map[string]any](`{"ENTITY_ID": 18446744073709551615, "SCORE": 1.50}`)
+	require.NoError(test, err)
+	assert.Equal(test, map[string]any{"ENTITY_ID": json.Number("18446744073709551615"), "SCORE": json.Number("1.50")},
+		actual)
+}
+
+func TestParseAs_error(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForParseAsError {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.ParseAs[typedRecord](testCase.jsonText)
+			require.ErrorContains(test, err, testCase.expected)
+			assert.Equal(test, typedRecord{DataSource: "", RecordID: "", Names: nil}, actual)
+		})
+	}
+}
+
+func TestParseAs_syntaxError(test *testing.T) {
+	test.Parallel()
+
+	var syntaxError *jsonutil.SyntaxError
+
+	_, err := jsonutil.ParseAs[typedRecord]("{\n\t\"DATA_SOURCE\": CUSTOMERS\n}")
+	require.ErrorAs(test, err, &syntaxError)
+	assert.Equal(test, 2, syntaxError.Line)
+	assert.Equal(test, 17, syntaxError.Column)
+}
+
+func TestRoundTrip(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForRoundTrip {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := jsonutil.RoundTrip[typedRecord](testCase.jsonText)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+func TestRoundTrip_error(test *testing.T) {
+	test.Parallel()
+
+	actual, err := jsonutil.RoundTrip[typedRecord](`{"RECORD_ID": 1001}`)
+	require.ErrorContains(test, err, "cannot unmarshal number into Go struct field typedRecord.RECORD_ID of type string")
+	assert.Equal(test, `{"RECORD_ID": 1001}`, actual)
+}
@@ -0,0 +1,603 @@
+package jsonutil
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	decimalBase       = 10
+	firstLowSurrogate = 0xdc00 // UTF-16 code units from 0xd800 are high surrogates, and from 0xdc00 low.
+	hexBase           = 16
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A strictValidator scans JSON text byte by byte, as encoding/json does, collecting issues.
+type strictValidator struct {
+	depth      int
+	issues     []ValidationIssue
+	lineStarts []int // Byte offset of the start of each line.
+	maxDepth   int
+	offset     int
+	text       string
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The String method describes a ValidationIssue, with its position.
+
+Output
+  - A description like `line 3, column 5 (offset 40): duplicate key "NAME_LAST"`.
+*/
+func (issue ValidationIssue) String() string {
+	return fmt.Sprintf("line %d, column %d (offset %d): %s", issue.Line, issue.Column, issue.Offset, issue.Message)
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The ValidateStrict function checks JSON text more strictly than [IsJSON] and [encoding/json], which
+accept the following silently. It reports:
+
+  - Objects with the same key more than once (e.g. two NAME_LAST keys), which [Normalize] would reduce to the last.
+  - Strings that are not valid UTF-8, which encoding/json would change to U+FFFD.
+  - \u escapes of half a UTF-16 surrogate pair (e.g. "\ud800"), which encoding/json would change to U+FFFD.
+  - Nesting of arrays and objects deeper than options.MaxDepth.
+  - Text longer than options.MaxBytes.
+
+It also reports the first syntax error, if the text is not JSON.
+Checking stops at a syntax error, at nesting that is too deep, and at text that is too long;
+otherwise every issue is reported.
+
+Input
+  - jsonText: The text to be checked.
+  - options: The limits on size and nesting.
+
+Output
+  - The issues found, in order of their offsets. Empty if the text is strictly valid JSON.
+*/
+func ValidateStrict(jsonText string, options ValidateOptions) []ValidationIssue {
+	validator := &strictValidator{
+		depth:      0,
+		issues:     []ValidationIssue{},
+		lineStarts: lineStarts(jsonText),
+		maxDepth:   options.MaxDepth,
+		offset:     0,
+		text:       jsonText,
+	}
+
+	if validator.maxDepth <= 0 {
+		validator.maxDepth = DefaultMaxDepth
+	}
+
+	if options.MaxBytes > 0 && len(jsonText) > options.MaxBytes {
+		validator.report(options.MaxBytes, ValidationTooLarge, "text is %d bytes, more than %d", len(jsonText),
+			options.MaxBytes)
+
+		return validator.issues
+	}
+
+	if !validator.value() {
+		return validator.issues
+	}
+
+	validator.skipWhitespace()
+
+	if validator.offset < len(jsonText) {
+		validator.syntaxError("after top-level value")
+	}
+
+	return validator.issues
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Scan an array, starting at its "[". Returns false if checking must stop.
+func (validator *strictValidator) array() bool {
+	if !validator.enter() {
+		return false
+	}
+
+	validator.skipWhitespace()
+
+	if validator.peek() == ']' {
+		validator.offset++
+		validator.depth--
+
+		return true
+	}
+
+	for {
+		if !validator.value() {
+			return false
+		}
+
+		validator.skipWhitespace()
+
+		switch validator.peek() {
+		case ',':
+			validator.offset++
+		case ']':
+			validator.offset++
+			validator.depth--
+
+			return true
+		default:
+			validator.syntaxError("after array element")
+
+			return false
+		}
+	}
+}
+
+// Step into an array or object. Returns false if it is nested too deeply.
+func (validator *strictValidator) enter() bool {
+	validator.depth++
+
+	if validator.depth > validator.maxDepth {
+		validator.report(validator.offset, ValidationTooDeep, "arrays and objects nested more than %d deep",
+			validator.maxDepth)
+
+		return false
+	}
+
+	validator.offset++ // The "[" or "{".
+
+	return true
+}
+
+// Report an invalid escape sequence in a string, in the words of encoding/json.
+func (validator *strictValidator) escapeError(escapeOffset int) {
+	if validator.offset >= len(validator.text) {
+		validator.syntaxError("")
+
+		return
+	}
+
+	_, size := utf8.DecodeRuneInString(validator.text[validator.offset:])
+	end := validator.offset + size
+
+	if validator.text[escapeOffset+1] == 'u' {
+		end = min(escapeOffset+len(`\u0000`), len(validator.text))
+	}
+
+	validator.report(escapeOffset, ValidationSyntax, "invalid escape sequence `%s` in string",
+		validator.text[escapeOffset:end])
+}
+
+// Scan \u and the 4 hex digits that follow it. Returns the UTF-16 code unit, or -1 after a syntax error.
+func (validator *strictValidator) hexEscape() rune {
+	var result rune
+
+	escapeOffset := validator.offset
+	validator.offset += len(`\u`)
+
+	for range len("0000") {
+		character := validator.peek()
+
+		switch {
+		case isDigit(character):
+			result = result*hexBase + rune(character-'0')
+		case 'a' <= character && character <= 'f':
+			result = result*hexBase + rune(character-'a'+decimalBase)
+		case 'A' <= character && character <= 'F':
+			result = result*hexBase + rune(character-'A'+decimalBase)
+		default:
+			validator.escapeError(escapeOffset)
+
+			return -1
+		}
+
+		validator.offset++
+	}
+
+	return result
+}
+
+// Scan a literal (true, false, or null). Returns false if checking must stop.
+func (validator *strictValidator) literal(literal string) bool {
+	for index := range len(literal) {
+		if validator.peek() != literal[index] {
+			validator.syntaxError("in literal " + literal + " (expecting " + quoteCharacter(literal[index:]) + ")")
+
+			return false
+		}
+
+		validator.offset++
+	}
+
+	return true
+}
+
+// Scan a number. Returns false if checking must stop.
+func (validator *strictValidator) number() bool {
+	if validator.peek() == '-' {
+		validator.offset++
+	}
+
+	switch character := validator.peek(); {
+	case character == '0':
+		validator.offset++
+	case isDigit(character):
+		validator.skipDigits()
+	default:
+		validator.syntaxError("in numeric literal")
+
+		return false
+	}
+
+	if validator.peek() == '.' {
+		validator.offset++
+
+		if !isDigit(validator.peek()) {
+			validator.syntaxError("in numeric literal")
+
+			return false
+		}
+
+		validator.skipDigits()
+	}
+
+	if character := validator.peek(); character == 'e' || character == 'E' {
+		validator.offset++
+
+		if character := validator.peek(); character == '+' || character == '-' {
+			validator.offset++
+		}
+
+		if !isDigit(validator.peek()) {
+			validator.syntaxError("in numeric literal")
+
+			return false
+		}
+
+		validator.skipDigits()
+	}
+
+	return true
+}
+
+// Scan an object, starting at its "{". Returns false if checking must stop.
+func (validator *strictValidator) object() bool {
+	if !validator.enter() {
+		return false
+	}
+
+	keyOffsets := map[string]int{} // Offset of the first occurrence of each key.
+
+	validator.skipWhitespace()
+
+	if validator.peek() == '}' {
+		validator.offset++
+		validator.depth--
+
+		return true
+	}
+
+	for {
+		validator.skipWhitespace()
+
+		if validator.peek() != '"' {
+			validator.syntaxError("looking for beginning of object key string")
+
+			return false
+		}
+
+		keyOffset := validator.offset
+
+		key, isString := validator.string()
+		if !isString {
+			return false
+		}
+
+		firstOffset, isDuplicate := keyOffsets[key]
+		if isDuplicate {
+			line, column := validator.position(firstOffset)
+			validator.report(keyOffset, ValidationDuplicateKey, "duplicate key %s (first at line %d, column %d)",
+				encodeString(key), line, column)
+		} else {
+			keyOffsets[key] = keyOffset
+		}
+
+		validator.skipWhitespace()
+
+		if validator.peek() != ':' {
+			validator.syntaxError("after object key")
+
+			return false
+		}
+
+		validator.offset++
+
+		if !validator.value() {
+			return false
+		}
+
+		validator.skipWhitespace()
+
+		switch validator.peek() {
+		case ',':
+			validator.offset++
+		case '}':
+			validator.offset++
+			validator.depth--
+
+			return true
+		default:
+			validator.syntaxError("after object key:value pair")
+
+			return false
+		}
+	}
+}
+
+// The byte at the offset, or 0 at the end of the text.
+func (validator *strictValidator) peek() byte {
+	if validator.offset >= len(validator.text) {
+		return 0
+	}
+
+	return validator.text[validator.offset]
+}
+
+// The line and column, counting characters, of a byte offset.
+func (validator *strictValidator) position(offset int) (int, int) {
+	return textPosition(validator.text, validator.lineStarts, offset)
+}
+
+func (validator *strictValidator) report(offset int, kind ValidationKind, format string, arguments ...any) {
+	line, column := validator.position(offset)
+
+	validator.issues = append(validator.issues, ValidationIssue{
+		Column:  column,
+		Kind:    kind,
+		Line:    line,
+		Message: fmt.Sprintf(format, arguments...),
+		Offset:  offset,
+	})
+}
+
+func (validator *strictValidator) skipDigits() {
+	for isDigit(validator.peek()) {
+		validator.offset++
+	}
+}
+
+func (validator *strictValidator) skipWhitespace() {
+	for {
+		switch validator.peek() {
+		case ' ', '\t', '\n', '\r':
+			validator.offset++
+		default:
+			return
+		}
+	}
+}
+
+/*
+Scan a string, starting at its opening quote. Returns the string, as encoding/json would decode it,
+and false if checking must stop.
+*/
+func (validator *strictValidator) string() (string, bool) {
+	var result strings.Builder
+
+	validator.offset++ // The opening quote.
+
+	isUTF8Reported := false
+
+	for {
+		character := validator.peek()
+
+		switch {
+		case validator.offset >= len(validator.text):
+			validator.syntaxError("")
+
+			return "", false
+		case character == '"':
+			validator.offset++
+
+			return result.String(), true
+		case character == '\\':
+			if !validator.stringEscape(&result) {
+				return "", false
+			}
+		case character < ' ':
+			validator.syntaxError("in string")
+
+			return "", false
+		case character < utf8.RuneSelf:
+			result.WriteByte(character)
+			validator.offset++
+		default:
+			decoded, size := utf8.DecodeRuneInString(validator.text[validator.offset:])
+			if decoded == utf8.RuneError && size == 1 && !isUTF8Reported {
+				validator.report(validator.offset, ValidationInvalidUTF8, "invalid UTF-8 in string")
+
+				isUTF8Reported = true // Once per string, rather than for every byte.
+			}
+
+			result.WriteRune(decoded)
+			validator.offset += size
+		}
+	}
+}
+
+// Scan an escape sequence in a string, appending the character to result. Returns false if checking must stop.
+func (validator *strictValidator) stringEscape(result *strings.Builder) bool {
+	escapeOffset := validator.offset
+
+	validator.offset++ // The backslash.
+
+	switch character := validator.peek(); character {
+	case '"', '\\', '/':
+		result.WriteByte(character)
+	case 'b':
+		result.WriteByte('\b')
+	case 'f':
+		result.WriteByte('\f')
+	case 'n':
+		result.WriteByte('\n')
+	case 'r':
+		result.WriteByte('\r')
+	case 't':
+		result.WriteByte('\t')
+	case 'u':
+		validator.offset = escapeOffset
+
+		return validator.unicodeEscape(result)
+	default:
+		validator.escapeError(escapeOffset)
+
+		return false
+	}
+
+	validator.offset++
+
+	return true
+}
+
+// Report a syntax error at the current offset, in the words of encoding/json.
+func (validator *strictValidator) syntaxError(context string) {
+	if validator.offset >= len(validator.text) {
+		validator.report(validator.offset, ValidationSyntax, "unexpected end of JSON input")
+
+		return
+	}
+
+	validator.report(validator.offset, ValidationSyntax, "invalid character %s %s",
+		quoteCharacter(validator.text[validator.offset:]), context)
+}
+
+// Scan a \u escape, and the low surrogate that follows a high surrogate. Returns false if checking must stop.
+func (validator *strictValidator) unicodeEscape(result *strings.Builder) bool {
+	escapeOffset := validator.offset
+
+	codeUnit := validator.hexEscape()
+
+	switch {
+	case codeUnit < 0:
+		return false
+	case !utf16.IsSurrogate(codeUnit):
+		result.WriteRune(codeUnit)
+
+		return true
+	}
+
+	if codeUnit < firstLowSurrogate && strings.HasPrefix(validator.text[validator.offset:], `\u`) {
+		lowOffset := validator.offset
+
+		lowCodeUnit := validator.hexEscape()
+		if lowCodeUnit < 0 {
+			return false
+		}
+
+		decoded := utf16.DecodeRune(codeUnit, lowCodeUnit)
+		if decoded != utf8.RuneError {
+			result.WriteRune(decoded)
+
+			return true
+		}
+
+		validator.offset = lowOffset // The second escape is not a low surrogate; scan it on its own.
+	}
+
+	validator.report(escapeOffset, ValidationLoneSurrogate, "lone UTF-16 surrogate %s",
+		validator.text[escapeOffset:escapeOffset+len(`\u0000`)])
+	result.WriteRune(utf8.RuneError)
+
+	return true
+}
+
+// Scan any JSON value. Returns false if checking must stop.
+func (validator *strictValidator) value() bool {
+	validator.skipWhitespace()
+
+	switch character := validator.peek(); {
+	case validator.offset >= len(validator.text):
+		validator.syntaxError("")
+
+		return false
+	case character == '{':
+		return validator.object()
+	case character == '[':
+		return validator.array()
+	case character == '"':
+		_, isString := validator.string()
+
+		return isString
+	case character == 't':
+		return validator.literal("true")
+	case character == 'f':
+		return validator.literal("false")
+	case character == 'n':
+		return validator.literal(Null)
+	case character == '-' || isDigit(character):
+		return validator.number()
+	default:
+		validator.syntaxError("looking for beginning of value")
+
+		return false
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func isDigit(character byte) bool {
+	return '0' <= character && character <= '9'
+}
+
+// The byte offset of the start of each line.
+func lineStarts(text string) []int {
+	result := []int{0}
+
+	for offset := range len(text) {
+		if text[offset] == '\n' {
+			result = append(result, offset+1)
+		}
+	}
+
+	return result
+}
+
+// Quote the character at the start of text as encoding/json does in error messages (e.g. 'x', '\n').
+func quoteCharacter(text string) string {
+	character, size := utf8.DecodeRuneInString(text)
+
+	switch {
+	case character == '\'':
+		return `'\''`
+	case character == '"':
+		return `'"'`
+	case character == utf8.RuneError && size == 1:
+		return fmt.Sprintf(`'\x%02x'`, text[0])
+	}
+
+	quoted := strconv.Quote(string(character))
+
+	return "'" + quoted[1:len(quoted)-1] + "'"
+}
+
+// The line and column, counting characters from 1, of a byte offset. starts is from lineStarts.
+func textPosition(text string, starts []int, offset int) (int, int) {
+	line := sort.Search(len(starts), func(index int) bool {
+		return starts[index] > offset
+	})
+
+	return line, utf8.RuneCountInString(text[starts[line-1]:offset]) + 1
+}
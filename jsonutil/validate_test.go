@@ -0,0 +1,278 @@
+package jsonutil_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCasesForValidateStrict = []struct {
+	name     string
+	jsonText string
+	options  jsonutil.ValidateOptions
+	expected []jsonutil.ValidationIssue
+}{
+	{
+		name:     "valid",
+		jsonText: `{"DATA_SOURCE": "CUSTOMERS", "NAMES": [{"NAME_LAST": "Smith"}, {"NAME_LAST": "Smith"}]}`,
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{},
+	},
+	{
+		name:     "valid-surrogate-pair",
+		jsonText: `"😀"`,
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{},
+	},
+	{
+		name:     "duplicate-key",
+		jsonText: `{"NAME_LAST": "Smith", "NAME_LAST": "Jones"}`,
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{
+			{
+				Column:  24,
+				Kind:    jsonutil.ValidationDuplicateKey,
+				Line:    1,
+				Message: `duplicate key "NAME_LAST" (first at line 1, column 2)`,
+				Offset:  23,
+			},
+		},
+	},
+	{
+		name:     "duplicate-keys-nested",
+		jsonText: "{\n  \"A\": {\"B\": 1, \"B\": 2},\n  \"A\": 3,\n  \"A\": 4\n}",
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{
+			{
+				Column:  17,
+				Kind:    jsonutil.ValidationDuplicateKey,
+				Line:    2,
+				Message: `duplicate key "B" (first at line 2, column 9)`,
+				Offset:  18,
+			},
+			{
+				Column:  3,
+				Kind:    jsonutil.ValidationDuplicateKey,
+				Line:    3,
+				Message: `duplicate key "A" (first at line 2, column 3)`,
+				Offset:  29,
+			},
+			{
+				Column:  3,
+				Kind:    jsonutil.ValidationDuplicateKey,
+				Line:    4,
+				Message: `duplicate key "A" (first at line 2, column 3)`,
+				Offset:  39,
+			},
+		},
+	},
+	{
+		name:     "duplicate-key-escaped",
+		jsonText: `{"A": 1, "\u0041": 2}`,
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{
+			{
+				Column:  10,
+				Kind:    jsonutil.ValidationDuplicateKey,
+				Line:    1,
+				Message: `duplicate key "A" (first at line 1, column 2)`,
+				Offset:  9,
+			},
+		},
+	},
+	{
+		name:     "invalid-utf8",
+		jsonText: "[\"ok\", \"\xff\xfe\", \"a\xc3\"]",
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{
+			{Column: 9, Kind: jsonutil.ValidationInvalidUTF8, Line: 1, Message: "invalid UTF-8 in string", Offset: 8},
+			{Column: 16, Kind: jsonutil.ValidationInvalidUTF8, Line: 1, Message: "invalid UTF-8 in string", Offset: 15},
+		},
+	},
+	{
+		name:     "lone-surrogates",
+		jsonText: `["\ud800", "\udc00", "\ud800A"]`,
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{
+			{Column: 3, Kind: jsonutil.ValidationLoneSurrogate, Line: 1, Message: `lone UTF-16 surrogate \ud800`, Offset: 2},
+			{Column: 13, Kind: jsonutil.ValidationLoneSurrogate, Line: 1, Message: `lone UTF-16 surrogate \udc00`, Offset: 12},
+			{Column: 23, Kind: jsonutil.ValidationLoneSurrogate, Line: 1, Message: `lone UTF-16 surrogate \ud800`, Offset: 22},
+		},
+	},
+	{
+		name:     "syntax-after-issues",
+		jsonText: `{"A": 1, "A": 2,}`,
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{
+			{
+				Column:  10,
+				Kind:    jsonutil.ValidationDuplicateKey,
+				Line:    1,
+				Message: `duplicate key "A" (first at line 1, column 2)`,
+				Offset:  9,
+			},
+			{
+				Column:  17,
+				Kind:    jsonutil.ValidationSyntax,
+				Line:    1,
+				Message: "invalid character '}' looking for beginning of object key string",
+				Offset:  16,
+			},
+		},
+	},
+	{
+		name:     "syntax-multiline",
+		jsonText: "{\n  \"NAME\": \"Émile\",\n  \"AGE\": 4x\n}",
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{
+			{
+				Column:  11,
+				Kind:    jsonutil.ValidationSyntax,
+				Line:    3,
+				Message: "invalid character 'x' after object key:value pair",
+				Offset:  32,
+			},
+		},
+	},
+	{
+		name:     "syntax-non-ascii-column",
+		jsonText: `["Émile" "Zoë"]`,
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{
+			{
+				Column:  10,
+				Kind:    jsonutil.ValidationSyntax,
+				Line:    1,
+				Message: "invalid character '\"' after array element",
+				Offset:  10,
+			},
+		},
+	},
+	{
+		name:     "syntax-end-of-input",
+		jsonText: `{"A": [1, 2`,
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{
+			{Column: 12, Kind: jsonutil.ValidationSyntax, Line: 1, Message: "unexpected end of JSON input", Offset: 11},
+		},
+	},
+	{
+		name:     "syntax-escape",
+		jsonText: `"\u12G4"`,
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{
+			{
+				Column:  2,
+				Kind:    jsonutil.ValidationSyntax,
+				Line:    1,
+				Message: "invalid escape sequence `\\u12G4` in string",
+				Offset:  1,
+			},
+		},
+	},
+	{
+		name:     "too-deep",
+		jsonText: `{"A": [[{"B": 1}]]}`,
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 3},
+		expected: []jsonutil.ValidationIssue{
+			{
+				Column:  9,
+				Kind:    jsonutil.ValidationTooDeep,
+				Line:    1,
+				Message: "arrays and objects nested more than 3 deep",
+				Offset:  8,
+			},
+		},
+	},
+	{
+		name:     "too-deep-default",
+		jsonText: strings.Repeat("[", jsonutil.DefaultMaxDepth+1) + strings.Repeat("]", jsonutil.DefaultMaxDepth+1),
+		options:  jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{
+			{
+				Column:  jsonutil.DefaultMaxDepth + 1,
+				Kind:    jsonutil.ValidationTooDeep,
+				Line:    1,
+				Message: "arrays and objects nested more than 10000 deep",
+				Offset:  jsonutil.DefaultMaxDepth,
+			},
+		},
+	},
+	{
+		name:     "too-large",
+		jsonText: `{"NAME_FULL": "Robert Smith"}`,
+		options:  jsonutil.ValidateOptions{MaxBytes: 16, MaxDepth: 0},
+		expected: []jsonutil.ValidationIssue{
+			{Column: 17, Kind: jsonutil.ValidationTooLarge, Line: 1, Message: "text is 29 bytes, more than 16", Offset: 16},
+		},
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface methods
+// ----------------------------------------------------------------------------
+
+func TestValidationIssue_String(test *testing.T) {
+	test.Parallel()
+
+	issue := jsonutil.ValidationIssue{
+		Column:  5,
+		Kind:    jsonutil.ValidationDuplicateKey,
+		Line:    3,
+		Message: `duplicate key "NAME_LAST"`,
+		Offset:  40,
+	}
+	assert.Equal(test, `line 3, column 5 (offset 40): duplicate key "NAME_LAST"`, issue.String())
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestValidateStrict(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForValidateStrict {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual := jsonutil.ValidateStrict(testCase.jsonText, testCase.options)
+			assert.Equal(test, testCase.expected, actual)
+		})
+	}
+}
+
+// For text with no duplicate keys, invalid UTF-8, or lone surrogates, syntax issues agree with encoding/json.
+func TestValidateStrict_agreesWithEncodingJSON(test *testing.T) {
+	test.Parallel()
+
+	for _, jsonText := range []string{
+		``, ` `, `1`, `-0.5e+10`, `01`, `1.`, `1.e5`, `-`, `"abc`, `"a\x"`, `"\u12"`, "\"a\x01\"", `tru`, `nulL`,
+		`[]`, `[1,]`, `[1 2]`, `{}`, `{"a":1,}`, `{"a" 1}`, `{1:2}`, `{"a":1}}`, `[1] x`, `'a'`, `é`,
+		" [ 1 , { \"a\" : [ true , false , null ] } ] ",
+	} {
+		test.Run(jsonText, func(test *testing.T) {
+			test.Parallel()
+
+			var syntaxError *json.SyntaxError
+
+			issues := jsonutil.ValidateStrict(jsonText, jsonutil.ValidateOptions{MaxBytes: 0, MaxDepth: 0})
+			err := json.Unmarshal([]byte(jsonText), new(any))
+
+			if err == nil {
+				assert.Empty(test, issues)
+
+				return
+			}
+
+			require.ErrorAs(test, err, &syntaxError)
+			require.Len(test, issues, 1)
+			assert.Equal(test, jsonutil.ValidationSyntax, issues[0].Kind)
+			assert.Equal(test, syntaxError.Error(), issues[0].Message)
+		})
+	}
+}
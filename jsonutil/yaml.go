@@ -0,0 +1,383 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	maxYAMLValues = 1000000 // Most values made from one YAML document, which aliases could otherwise multiply.
+	yamlIndent    = 2       // Spaces per level of YAML written by JSONToYAML.
+)
+
+// Bases of YAML integers with the prefixes 0b and 0o (and 0x, see hexBase).
+const (
+	binaryBase = 2
+	octalBase  = 8
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A yamlConverter converts YAML nodes to JSON, expanding aliases.
+type yamlConverter struct {
+	expanding  map[*yaml.Node]bool // Anchored nodes being converted, to find aliases inside them to themselves.
+	valueCount int
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The JSONToYAML function converts JSON to YAML (e.g. to maintain Senzing engine settings or records in YAML).
+Members are kept in their original order, and numbers as written.
+Strings that YAML would read as another type (e.g. "true" and "123") are quoted.
+
+Input
+  - jsonText: The JSON text to be converted.
+
+Output
+  - The YAML document, in block style, ending with a newline.
+  - An error if the text is not JSON.
+*/
+func JSONToYAML(jsonText string) (string, error) {
+	node, err := parseOrdered(jsonText)
+	if err != nil {
+		return jsonText, err
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(yamlIndent)
+
+	err = encoder.Encode(yamlNode(node))
+	if err != nil {
+		return jsonText, wraperror.Errorf(err, "Encode")
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return jsonText, wraperror.Errorf(err, "Close")
+	}
+
+	return buffer.String(), nil
+}
+
+/*
+The YAMLToJSON function converts a YAML document to JSON, compact as by [Normalize],
+but with members in the order of the YAML mappings. To sort the members, use [Normalize] on the result.
+
+Values are converted by their YAML 1.2 types: e.g. yes and 1_000 become "yes" and 1000,
+and timestamps become strings. Aliases are expanded, and merge keys (<<) are applied.
+Mapping keys must be scalars, which become strings (e.g. the key 1 becomes "1").
+
+Input
+  - yamlText: The YAML document to be converted. If empty, the result is null.
+
+Output
+  - The JSON text, compact.
+  - An error if the text is not one YAML document, or has values that JSON cannot represent (e.g. .inf).
+*/
+func YAMLToJSON(yamlText string) (string, error) {
+	var document yaml.Node
+
+	decoder := yaml.NewDecoder(strings.NewReader(yamlText))
+
+	err := decoder.Decode(&document)
+	if errors.Is(err, io.EOF) {
+		return Null, nil
+	}
+
+	if err != nil {
+		return yamlText, wraperror.Errorf(err, "Decode")
+	}
+
+	var unused yaml.Node
+
+	err = decoder.Decode(&unused)
+	if !errors.Is(err, io.EOF) {
+		return yamlText, wraperror.Errorf(errForPackage, "want one YAML document, got more than one")
+	}
+
+	converter := &yamlConverter{
+		expanding:  map[*yaml.Node]bool{},
+		valueCount: 0,
+	}
+
+	node, err := converter.convert(&document, "$")
+	if err != nil {
+		return yamlText, err
+	}
+
+	var builder strings.Builder
+
+	writeCompact(&builder, node)
+
+	return builder.String(), nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Convert a YAML node, at the selector path, to JSON.
+func (converter *yamlConverter) convert(node *yaml.Node, path string) (formatNode, error) {
+	result := formatNode{
+		children: nil,
+		keys:     nil,
+		kind:     0,
+		scalar:   Null,
+	}
+
+	converter.valueCount++
+	if converter.valueCount > maxYAMLValues {
+		return result, wraperror.Errorf(errForPackage, "at %s, YAML makes more than %d values", path, maxYAMLValues)
+	}
+
+	if node.Anchor != "" {
+		if converter.expanding[node] {
+			return result, wraperror.Errorf(errForPackage, "at %s, alias *%s contains itself", path, node.Anchor)
+		}
+
+		converter.expanding[node] = true
+		defer delete(converter.expanding, node)
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return result, nil
+		}
+
+		return converter.convert(node.Content[0], path)
+	case yaml.AliasNode:
+		return converter.convert(node.Alias, path)
+	case yaml.SequenceNode:
+		result.kind = '['
+		result.children = make([]formatNode, 0, len(node.Content))
+
+		for index, element := range node.Content {
+			child, err := converter.convert(element, appendIndex(path, index))
+			if err != nil {
+				return result, err
+			}
+
+			result.children = append(result.children, child)
+		}
+
+		return result, nil
+	case yaml.MappingNode:
+		return converter.object(node, path)
+	case yaml.ScalarNode:
+		scalar, err := jsonScalarFromYAML(node, path)
+		result.scalar = scalar
+
+		return result, err
+	default:
+		return result, wraperror.Errorf(errForPackage, "at %s, unexpected YAML node", path)
+	}
+}
+
+// Convert a YAML mapping to a JSON object. Members from merge keys (<<) are added where the mapping does not set them.
+func (converter *yamlConverter) object(node *yaml.Node, path string) (formatNode, error) {
+	result := formatNode{
+		children: make([]formatNode, 0, len(node.Content)/2), //nolint:mnd
+		keys:     make([]string, 0, len(node.Content)/2),     //nolint:mnd
+		kind:     '{',
+		scalar:   "",
+	}
+
+	isSet := map[string]bool{} // Keys set by the mapping itself, rather than merged.
+
+	for index := 0; index < len(node.Content); index += 2 {
+		if resolveYAMLAlias(node.Content[index]).ShortTag() != "!!merge" {
+			isSet[resolveYAMLAlias(node.Content[index]).Value] = true
+		}
+	}
+
+	isAdded := map[string]bool{}
+
+	for index := 0; index < len(node.Content); index += 2 {
+		keyNode, valueNode := resolveYAMLAlias(node.Content[index]), node.Content[index+1]
+
+		if keyNode.Kind != yaml.ScalarNode {
+			return result, wraperror.Errorf(errForPackage, "at %s, a key is not a scalar", path)
+		}
+
+		if keyNode.ShortTag() != "!!merge" {
+			child, err := converter.convert(valueNode, appendKey(path, keyNode.Value))
+			if err != nil {
+				return result, err
+			}
+
+			result.keys = append(result.keys, keyNode.Value)
+			result.children = append(result.children, child)
+			isAdded[keyNode.Value] = true
+
+			continue
+		}
+
+		// A merge key: its value is a mapping, or a sequence of mappings.
+
+		merged := []*yaml.Node{valueNode}
+		if resolveYAMLAlias(valueNode).Kind == yaml.SequenceNode {
+			merged = resolveYAMLAlias(valueNode).Content
+		}
+
+		for _, mergedNode := range merged {
+			object, err := converter.convert(mergedNode, path)
+			if err != nil {
+				return result, err
+			}
+
+			if object.kind != '{' {
+				return result, wraperror.Errorf(errForPackage, "at %s, << must merge mappings", path)
+			}
+
+			for position, key := range object.keys {
+				if !isSet[key] && !isAdded[key] {
+					result.keys = append(result.keys, key)
+					result.children = append(result.children, object.children[position])
+					isAdded[key] = true
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Convert a YAML scalar to a JSON scalar, by its YAML type.
+func jsonScalarFromYAML(node *yaml.Node, path string) (string, error) {
+	switch node.ShortTag() {
+	case "!!null":
+		return Null, nil
+	case "!!bool":
+		return strings.ToLower(node.Value), nil
+	case "!!int":
+		number, isNumber := strictNumber(node.Value)
+		if isNumber {
+			return number, nil
+		}
+
+		value, isInteger := yamlInteger(node.Value)
+		if isInteger {
+			return value.String(), nil
+		}
+	case "!!float":
+		number, isNumber := strictNumber(node.Value)
+		if isNumber {
+			return number, nil
+		}
+
+		value, err := strconv.ParseFloat(strings.ReplaceAll(node.Value, "_", ""), 64)
+		if err == nil && !math.IsInf(value, 0) && !math.IsNaN(value) {
+			number, err := json.Marshal(value)
+
+			return string(number), wraperror.Errorf(err, "Marshal")
+		}
+
+		return "", wraperror.Errorf(errForPackage, "at %s, %s cannot be written in JSON", path, node.Value)
+	default:
+		return encodeString(node.Value), nil // Strings, timestamps, binary, and other tags.
+	}
+
+	return "", wraperror.Errorf(errForPackage, "at %s, %s is not an integer", path, node.Value)
+}
+
+// Follow an alias to the node it refers to.
+func resolveYAMLAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	return node
+}
+
+/*
+Parse a YAML integer. Only the prefixes 0x, 0o, and 0b change the base:
+as in YAML 1.2, a leading zero does not make an integer octal (e.g. 01234 is 1234, not 668).
+*/
+func yamlInteger(text string) (*big.Int, bool) {
+	digits := strings.ReplaceAll(text, "_", "")
+	sign := ""
+
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+
+	base := decimalBase
+
+	if len(digits) > len("0x") && digits[0] == '0' {
+		switch digits[1] {
+		case 'x', 'X':
+			base = hexBase
+		case 'o', 'O':
+			base = octalBase
+		case 'b', 'B':
+			base = binaryBase
+		}
+
+		if base != decimalBase {
+			digits = digits[2:]
+		}
+	}
+
+	if len(digits) == 0 || digits[0] == '+' || digits[0] == '-' {
+		return nil, false
+	}
+
+	return new(big.Int).SetString(sign+digits, base)
+}
+
+// Make the YAML node for a JSON value.
+func yamlNode(node formatNode) *yaml.Node {
+	result := &yaml.Node{ //nolint:exhaustruct
+		Kind:  yaml.ScalarNode,
+		Value: node.scalar,
+	}
+
+	switch {
+	case node.kind == '{':
+		result.Kind = yaml.MappingNode
+		result.Value = ""
+
+		for index, child := range node.children {
+			result.Content = append(result.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: node.keys[index]}, //nolint:exhaustruct
+				yamlNode(child))
+		}
+	case node.kind == '[':
+		result.Kind = yaml.SequenceNode
+		result.Value = ""
+
+		for _, child := range node.children {
+			result.Content = append(result.Content, yamlNode(child))
+		}
+	case node.scalar[0] == '"':
+		_ = json.Unmarshal([]byte(node.scalar), &result.Value) // The scalar was encoded by encodeString.
+		result.Tag = "!!str"
+	case node.scalar == Null:
+		result.Tag = "!!null"
+	case node.scalar == "true" || node.scalar == "false":
+		result.Tag = "!!bool"
+	}
+
+	return result
+}
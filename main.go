@@ -4,77 +4,248 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-
-	"github.com/senzing-garage/go-helpers/jsonutil"
-	"github.com/senzing-garage/go-helpers/settings"
+	"os/signal"
+	"strings"
+	"syscall"
 )
 
-func main() {
-	if len(os.Args) > 2 && os.Args[1] == "-j" {
-		args := os.Args[2:]
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
 
-		for _, jsonText := range args {
-			normalized, err := jsonutil.Normalize(jsonText)
-			failOnError(err)
+// A command handles the arguments following its name on the command line.
+type command func(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error
 
-			normSorted, _ := jsonutil.NormalizeAndSort(jsonText)
+// stringList is a flag.Value that can be specified multiple times (e.g. --key A --key B).
+type stringList []string
 
-			outputln("- - - - - - - - - - - - - - - - - - - - ")
-			outputln(normalized)
-			outputln()
-			outputln(normSorted)
-			outputln()
-		}
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
 
-		return
-	}
+// Exit codes.
+const (
+	exitSuccess = 0
+	exitFailure = 1 // The command ran, but failed (e.g. settings did not verify).
+	exitUsage   = 2 // The command line was incorrect.
+)
+
+const usage = `Usage: go-helpers <group> <command> [options] [input...]
+
+Groups and commands:
+
+  settings build      Build Senzing engine configuration JSON from options and SENZING_TOOLS_* environment variables.
+  settings verify     Verify Senzing engine configuration JSON.
+  settings redact     Print Senzing engine configuration JSON with database passwords redacted.
+  settings explain    Describe Senzing engine configuration JSON.
+
+  db uri-to-url       Convert Senzing database URIs to database URLs.
+  db url-to-uri       Convert database URLs to Senzing database URIs.
+
+  json canonical      Canonicalize JSON (RFC 8785), or print its SHA-256 hash (--hash).
+  json flatten        Flatten JSON objects to one level, with keys like RECORDS.0.DATA_SOURCE,
+                      with options --separator and --arrays (indexed, bracketed, or keep).
+  json format         Format JSON for reading, with options --indent, --width, --sort-keys, and --color.
+  json from-toml      Convert a TOML document to JSON, keeping the order of keys.
+  json from-yaml      Convert a YAML document to JSON, keeping the order of keys.
+  json normalize      Normalize JSON, or JSON with comments, trailing commas, and single quotes (--lenient).
+  json rename-keys    Rename keys at any depth to a naming convention (--case camel, kebab, lower-snake,
+                      pascal, or upper-snake), or with a mapping (--rename OLD=NEW).
+  json sort           Normalize JSON and sort JSON arrays, or only selected arrays (--path),
+                      ordering objects by the values of keys (--key).
+  json strip          Remove keys (--key) or selected values (--path) from JSON.
+  json redact         Replace the values of keys (--key) or selected values (--path) with null,
+                      and optionally replace SSNs, email addresses, phone numbers, etc. (--pii).
+  json to-toml        Convert a JSON object to TOML.
+  json to-yaml        Convert JSON to YAML.
+  json truncate       Truncate JSON to a number of lines (--lines), or keep it valid JSON while limiting
+                      its size (--bytes, --nodes), depth (--depth), arrays (--items), and strings (--string-length).
+  json unflatten      Nest flattened JSON objects again, with the options of json flatten.
+  json validate       Check JSON strictly, reporting duplicate keys, invalid UTF-8, and lone surrogates
+                      by line and column, with limits --max-bytes and --max-depth.
+
+  record from-csv     Convert CSV with a header row to JSON lines records, with options --delimiter,
+                      --lazy-quotes, --data-source, and --flatten (for columns like NAMES.0.NAME_FULL).
+  record to-csv       Convert JSON lines records to CSV, with options --delimiter, --quote-all, and --flatten.
+
+Inputs:
+
+  text     Used as-is.
+  @path    The contents of the file at path.
+  -        Standard input.
+
+If no input is given, standard input is read.
+The settings verify, redact, and explain commands instead build settings from SENZING_TOOLS_* environment variables.
+
+Exit codes: 0 success, 1 failure, 2 incorrect usage.
+`
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	errBadDelimiter        = errors.New("want one character")
+	errBadRename           = errors.New("want OLD=NEW")
+	errConflictingOptions  = errors.New("conflicting options")
+	errNotJSON             = errors.New("not JSON")
+	errNotStrictJSON       = errors.New("not strictly valid JSON")
+	errUnexpectedArguments = errors.New("unexpected arguments")
+	errUnknownArrays       = errors.New("want indexed, bracketed, or keep")
+	errUnknownKeyCase      = errors.New("want camel, kebab, lower-snake, pascal, or upper-snake")
+	errUsage               = errors.New("usage")
+)
 
-	ctx := context.TODO()
+var commandGroups = map[string]map[string]command{
+	"db": {
+		"uri-to-url": dbURIToURL,
+		"url-to-uri": dbURLToURI,
+	},
+	"json": {
+		"canonical":   jsonCanonical,
+		"flatten":     jsonFlatten,
+		"format":      jsonFormat,
+		"from-toml":   jsonFromTOML,
+		"from-yaml":   jsonFromYAML,
+		"normalize":   jsonNormalize,
+		"redact":      jsonRedact,
+		"rename-keys": jsonRenameKeys,
+		"sort":        jsonSort,
+		"strip":       jsonStrip,
+		"to-toml":     jsonToTOML,
+		"to-yaml":     jsonToYAML,
+		"truncate":    jsonTruncate,
+		"unflatten":   jsonUnflatten,
+		"validate":    jsonValidate,
+	},
+	"record": {
+		"from-csv": recordFromCSV,
+		"to-csv":   recordToCSV,
+	},
+	"settings": {
+		"build":   settingsBuild,
+		"explain": settingsExplain,
+		"redact":  settingsRedact,
+		"verify":  settingsVerify,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Main
+// ----------------------------------------------------------------------------
 
-	// ------------------------------------------------------------------------
-	// --- Build JSON from environment variables.
-	// ------------------------------------------------------------------------
+func main() {
+	// Interrupting the command (e.g. with Ctrl-C) cancels the context, stopping work like settings verification.
 
-	iniParams, err := settings.BuildSimpleSettingsUsingEnvVars()
-	failOnError(err)
-	outputln(iniParams)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	exitCode := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
 
-	// ------------------------------------------------------------------------
-	// --- Verify parameters
-	// ------------------------------------------------------------------------
+	stop()
+	os.Exit(exitCode)
+}
 
-	err = settings.VerifySettings(ctx, iniParams)
-	failOnError(err)
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
 
-	// ------------------------------------------------------------------------
-	// --- Build JSON from map of key/values.
-	// ------------------------------------------------------------------------
+func (list *stringList) Set(value string) error {
+	*list = append(*list, value)
 
-	attributeMap := map[string]string{
-		"licenseStringBase64": "8BD296A26F2034AAB436045...",
-		"senzingDirectory":    "/path/to/senzing",
-		"configPath":          "/another/path/for/config",
-		"resourcePath":        "/yet/another/path/to/resources",
-		"supportPath":         "/final/path/to/support",
-	}
+	return nil
+}
 
-	iniParams2, err := settings.BuildSimpleSettingsUsingMap(attributeMap)
-	failOnError(err)
-	outputln(iniParams2)
+func (list *stringList) String() string {
+	return strings.Join(*list, ",")
 }
 
 // ----------------------------------------------------------------------------
 // Private functions
 // ----------------------------------------------------------------------------
 
-func failOnError(err error) {
-	if err != nil {
-		panic(err.Error())
+func newFlagSet(name string) *flag.FlagSet {
+	result := flag.NewFlagSet(name, flag.ContinueOnError)
+	result.SetOutput(io.Discard)
+
+	return result
+}
+
+/*
+Resolve each input: "-" is standard input, "@path" is a file, anything else is used as-is.
+If there are no inputs, standard input is read.
+*/
+func readInputs(inputs []string, stdin io.Reader) ([]string, error) {
+	if len(inputs) == 0 {
+		inputs = []string{"-"}
+	}
+
+	result := make([]string, 0, len(inputs))
+
+	for _, input := range inputs {
+		switch {
+		case input == "-":
+			contents, err := io.ReadAll(stdin)
+			if err != nil {
+				return result, fmt.Errorf("reading standard input: %w", err)
+			}
+
+			result = append(result, string(contents))
+		case strings.HasPrefix(input, "@"):
+			contents, err := os.ReadFile(input[1:])
+			if err != nil {
+				return result, fmt.Errorf("reading %s: %w", input[1:], err)
+			}
+
+			result = append(result, string(contents))
+		default:
+			result = append(result, input)
+		}
+	}
+
+	return result, nil
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) < 2 { //nolint:mnd
+		fmt.Fprint(stderr, usage)
+
+		return exitUsage
+	}
+
+	commands, isGroup := commandGroups[args[0]]
+	if !isGroup {
+		fmt.Fprintf(stderr, "unknown command group: %s\n\n%s", args[0], usage)
+
+		return exitUsage
+	}
+
+	aCommand, isCommand := commands[args[1]]
+	if !isCommand {
+		fmt.Fprintf(stderr, "unknown command: %s %s\n\n%s", args[0], args[1], usage)
+
+		return exitUsage
+	}
+
+	err := aCommand(ctx, args[2:], stdin, stdout)
+
+	switch {
+	case err == nil:
+		return exitSuccess
+	case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+		fmt.Fprintf(stderr, "%s\n\n%s", err, usage)
+
+		return exitUsage
+	default:
+		fmt.Fprintln(stderr, err)
+
+		return exitFailure
 	}
 }
 
-func outputln(message ...any) {
-	fmt.Println(message...) //nolint
+func usageError(err error) error {
+	return fmt.Errorf("%w: %w", errUsage, err)
 }
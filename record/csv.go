@@ -0,0 +1,359 @@
+package record
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+const (
+	byteOrderMark    = "\uFEFF" // Written by some spreadsheets at the start of CSV files.
+	defaultDelimiter = ','
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// CSVOptions controls the conversion of records between CSV and JSON lines.
+type CSVOptions struct {
+	DataSource string                   // CSV to JSON: the DATA_SOURCE of rows that have none.
+	Delimiter  rune                     // Between fields. If 0, ',' is used.
+	Flatten    *jsonutil.FlattenOptions // If not nil, columns are flattened keys (e.g. "NAMES.0.NAME_FULL").
+	LazyQuotes bool                     // CSV to JSON: allow quotes in unquoted fields and undoubled quotes.
+	QuoteAll   bool                     // JSON to CSV: quote every field, not only those that need it.
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+/*
+The CSVToJSONLines function converts CSV with a header row to JSON lines, one record per row.
+The header row names the JSON keys (e.g. DATA_SOURCE, RECORD_ID, NAME_FULL), as in the
+Generic Entity Specification.
+
+Values are JSON strings; empty fields are left out.
+If options.Flatten is not nil, column names are flattened keys, which are nested by [jsonutil.Unflatten]
+(e.g. "NAMES.0.NAME_FULL" becomes {"NAMES": [{"NAME_FULL": ...}]}).
+Each line is normalized by [jsonutil.Normalize], and must be a valid record (see [Validate]).
+
+Input
+  - ctx: A context to control lifecycle.
+  - reader: The source of CSV.
+  - writer: The destination of the JSON lines.
+  - options: Controls the delimiter, quoting, and keys.
+
+Output
+  - An error identifying the CSV line, if a row is not well formed or not a valid record.
+*/
+func CSVToJSONLines(ctx context.Context, reader io.Reader, writer io.Writer, options CSVOptions) error {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = csvDelimiter(options)
+	csvReader.LazyQuotes = options.LazyQuotes
+
+	header, err := csvReader.Read()
+	if errors.Is(err, io.EOF) {
+		return wraperror.Errorf(szerrors.NewError(3003), wraperror.NoMessage)
+	}
+
+	if err != nil {
+		return wraperror.Errorf(err, "csv.Read")
+	}
+
+	header[0] = strings.TrimPrefix(header[0], byteOrderMark)
+
+	err = checkHeader(header)
+	if err != nil {
+		return err
+	}
+
+	bufferedWriter := bufio.NewWriter(writer)
+
+	for {
+		err = ctx.Err()
+		if err != nil {
+			return wraperror.Errorf(err, "ctx.Err")
+		}
+
+		row, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return wraperror.Errorf(err, "csv.Read")
+		}
+
+		line, _ := csvReader.FieldPos(0)
+
+		jsonLine, err := rowToJSON(header, row, options)
+		if err != nil {
+			return wraperror.Errorf(err, "CSV line %d", line)
+		}
+
+		_, err = bufferedWriter.WriteString(jsonLine + "\n")
+		if err != nil {
+			return wraperror.Errorf(err, "WriteString")
+		}
+	}
+
+	return wraperror.Errorf(bufferedWriter.Flush(), "Flush")
+}
+
+/*
+The JSONLinesToCSV function converts JSON lines, one record per line, to CSV with a header row.
+Blank lines are skipped.
+
+The columns are DATA_SOURCE, RECORD_ID, and then the other keys of the records, in the order they first appear.
+Strings are written as is, other values as JSON, and nulls and missing keys as empty fields.
+If options.Flatten is not nil, records are first flattened by [jsonutil.FlattenObject],
+so that nested values get columns of their own (e.g. "NAMES.0.NAME_FULL").
+Otherwise nested arrays and objects are written as JSON.
+
+As the columns are only known after the last record, all records are held in memory.
+
+Input
+  - ctx: A context to control lifecycle.
+  - reader: The source of JSON lines.
+  - writer: The destination of the CSV.
+  - options: Controls the delimiter, quoting, and columns.
+
+Output
+  - An error identifying the line, if a line is not a valid record (see [Validate]).
+*/
+func JSONLinesToCSV(ctx context.Context, reader io.Reader, writer io.Writer, options CSVOptions) error {
+	var (
+		columns  = []string{"DATA_SOURCE", "RECORD_ID"}
+		isColumn = map[string]bool{"DATA_SOURCE": true, "RECORD_ID": true}
+		rows     []map[string]string
+	)
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, jsonutil.DefaultMaxLineSize)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		err := ctx.Err()
+		if err != nil {
+			return wraperror.Errorf(err, "ctx.Err")
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		row, keys, err := jsonToRow(line, options)
+		if err != nil {
+			return wraperror.Errorf(err, "line %d", lineNumber)
+		}
+
+		for _, key := range keys {
+			if !isColumn[key] {
+				isColumn[key] = true
+				columns = append(columns, key)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return wraperror.Errorf(err, "Scan")
+	}
+
+	bufferedWriter := bufio.NewWriter(writer)
+	writeCSVRow(bufferedWriter, columns, options)
+
+	fields := make([]string, len(columns))
+
+	for _, row := range rows {
+		for index, column := range columns {
+			fields[index] = row[column]
+		}
+
+		writeCSVRow(bufferedWriter, fields, options)
+	}
+
+	return wraperror.Errorf(bufferedWriter.Flush(), "Flush")
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Column names must be unique, so that no field is lost.
+func checkHeader(header []string) error {
+	seen := make(map[string]bool, len(header))
+
+	for _, column := range header {
+		if seen[column] {
+			return wraperror.Errorf(szerrors.NewError(3004), "column %s", column)
+		}
+
+		seen[column] = true
+	}
+
+	return nil
+}
+
+func csvDelimiter(options CSVOptions) rune {
+	if options.Delimiter == 0 {
+		return defaultDelimiter
+	}
+
+	return options.Delimiter
+}
+
+// Whether a field must be quoted to be read back as is. See encoding/csv.Writer.
+func fieldNeedsQuotes(field string, delimiter rune) bool {
+	if field == "" {
+		return false
+	}
+
+	return strings.ContainsRune(field, delimiter) || strings.ContainsAny(field, "\"\r\n") ||
+		field[0] == ' ' || field[0] == '\t' || field == `\.`
+}
+
+// Convert a record to CSV fields by key. Also returns the keys, in order.
+func jsonToRow(line string, options CSVOptions) (map[string]string, []string, error) {
+	_, err := Validate(line)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if options.Flatten != nil {
+		line, err = jsonutil.FlattenObject(line, *options.Flatten)
+		if err != nil {
+			return nil, nil, wraperror.Errorf(err, "FlattenObject")
+		}
+	}
+
+	// Read the members in order, keeping each value as JSON.
+
+	decoder := json.NewDecoder(strings.NewReader(line))
+	row := map[string]string{}
+	keys := []string{}
+
+	_, err = decoder.Token() // The opening "{".
+	if err != nil {
+		return nil, nil, wraperror.Errorf(err, "Token")
+	}
+
+	for decoder.More() {
+		var (
+			key   string
+			value json.RawMessage
+		)
+
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, wraperror.Errorf(err, "Token")
+		}
+
+		key, _ = token.(string)
+
+		err = decoder.Decode(&value)
+		if err != nil {
+			return nil, nil, wraperror.Errorf(err, "Decode")
+		}
+
+		row[key] = jsonToField(value)
+		keys = append(keys, key)
+	}
+
+	return row, keys, nil
+}
+
+// Strings are written as is, other values as compact JSON, and null as an empty field.
+func jsonToField(value json.RawMessage) string {
+	var text string
+
+	switch {
+	case string(value) == "null":
+		return ""
+	case json.Unmarshal(value, &text) == nil:
+		return text
+	}
+
+	var buffer bytes.Buffer
+
+	_ = json.Compact(&buffer, value) // The value was decoded, so is valid JSON.
+
+	return buffer.String()
+}
+
+// Convert a CSV row to a valid, normalized record.
+func rowToJSON(header []string, row []string, options CSVOptions) (string, error) {
+	members := make([]string, 0, len(header)+1)
+	hasDataSource := false
+
+	for index, column := range header {
+		if row[index] == "" {
+			continue
+		}
+
+		if column == "DATA_SOURCE" {
+			hasDataSource = true
+		}
+
+		members = append(members, jsonString(column)+":"+jsonString(row[index]))
+	}
+
+	if !hasDataSource && options.DataSource != "" {
+		members = append(members, jsonString("DATA_SOURCE")+":"+jsonString(options.DataSource))
+	}
+
+	jsonLine := "{" + strings.Join(members, ",") + "}"
+
+	var err error
+
+	if options.Flatten != nil {
+		jsonLine, err = jsonutil.Unflatten(jsonLine, *options.Flatten)
+		if err != nil {
+			return "", wraperror.Errorf(err, "Unflatten")
+		}
+	}
+
+	jsonLine, err = jsonutil.Normalize(jsonLine)
+	if err != nil {
+		return "", wraperror.Errorf(err, "Normalize")
+	}
+
+	_, err = Validate(jsonLine)
+
+	return jsonLine, err
+}
+
+func jsonString(text string) string {
+	result, _ := json.Marshal(text) // Marshaling a string cannot fail.
+
+	return string(result)
+}
+
+func writeCSVRow(writer *bufio.Writer, fields []string, options CSVOptions) {
+	delimiter := csvDelimiter(options)
+
+	for index, field := range fields {
+		if index > 0 {
+			_, _ = writer.WriteRune(delimiter)
+		}
+
+		if options.QuoteAll || fieldNeedsQuotes(field, delimiter) {
+			field = `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+		}
+
+		_, _ = writer.WriteString(field)
+	}
+
+	_ = writer.WriteByte('\n') // Write errors are returned by Flush.
+}
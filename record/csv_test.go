@@ -0,0 +1,251 @@
+package record_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/jsonutil"
+	"github.com/senzing-garage/go-helpers/record"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCasesForCSVToJSONLines = []struct {
+	name     string
+	csvText  string
+	options  record.CSVOptions
+	expected string
+}{
+	{
+		name:     "basic",
+		csvText:  "DATA_SOURCE,RECORD_ID,NAME_FULL,PHONE_NUMBER\nCUSTOMERS,1001,Robert Smith,\nCUSTOMERS,1002,\"Smith, Bob\",555-1212\n",
+		options:  record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected: `{"DATA_SOURCE":"CUSTOMERS","NAME_FULL":"Robert Smith","RECORD_ID":"1001"}` + "\n" + `{"DATA_SOURCE":"CUSTOMERS","NAME_FULL":"Smith, Bob","PHONE_NUMBER":"555-1212","RECORD_ID":"1002"}` + "\n",
+	},
+	{
+		name:     "byte-order-mark-and-crlf",
+		csvText:  "\uFEFFDATA_SOURCE,RECORD_ID\r\nCUSTOMERS,1001\r\n",
+		options:  record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected: `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}` + "\n",
+	},
+	{
+		name:     "data-source",
+		csvText:  "RECORD_ID,DATA_SOURCE\n1001,\n1002,WATCHLIST\n",
+		options:  record.CSVOptions{DataSource: "CUSTOMERS", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected: `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001"}` + "\n" + `{"DATA_SOURCE":"WATCHLIST","RECORD_ID":"1002"}` + "\n",
+	},
+	{
+		name:     "delimiter",
+		csvText:  "DATA_SOURCE\tRECORD_ID\tNAME_FULL\nCUSTOMERS\t1001\tSmith, Robert\n",
+		options:  record.CSVOptions{DataSource: "", Delimiter: '\t', Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected: `{"DATA_SOURCE":"CUSTOMERS","NAME_FULL":"Smith, Robert","RECORD_ID":"1001"}` + "\n",
+	},
+	{
+		name:     "flatten",
+		csvText:  "DATA_SOURCE,RECORD_ID,NAMES[0].NAME_FULL,NAMES[1].NAME_FULL\nCUSTOMERS,1001,Robert Smith,Bob Smith\n",
+		options:  record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: &jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysBracketed, Separator: ""}, LazyQuotes: false, QuoteAll: false},
+		expected: `{"DATA_SOURCE":"CUSTOMERS","NAMES":[{"NAME_FULL":"Robert Smith"},{"NAME_FULL":"Bob Smith"}],"RECORD_ID":"1001"}` + "\n",
+	},
+	{
+		name:     "lazy-quotes",
+		csvText:  "DATA_SOURCE,RECORD_ID,NAME_FULL\nCUSTOMERS,1001,Robert \"Bob\" Smith\n",
+		options:  record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: true, QuoteAll: false},
+		expected: `{"DATA_SOURCE":"CUSTOMERS","NAME_FULL":"Robert \"Bob\" Smith","RECORD_ID":"1001"}` + "\n",
+	},
+	{
+		name:     "header-only",
+		csvText:  "DATA_SOURCE,RECORD_ID\n",
+		options:  record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected: "",
+	},
+}
+
+var testCasesForCSVToJSONLinesErrors = []struct {
+	name     string
+	csvText  string
+	options  record.CSVOptions
+	expected string
+}{
+	{
+		name:     "empty",
+		csvText:  "",
+		options:  record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected: `"id":"3003"`,
+	},
+	{
+		name:     "repeated-column",
+		csvText:  "DATA_SOURCE,RECORD_ID,RECORD_ID\n",
+		options:  record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected: `"text": "column RECORD_ID", "error": {"id":"3004"}`,
+	},
+	{
+		name:     "missing-record-id",
+		csvText:  "DATA_SOURCE,RECORD_ID\nCUSTOMERS,1001\nCUSTOMERS,\n",
+		options:  record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected: "CSV line 3",
+	},
+	{
+		name:     "missing-data-source",
+		csvText:  "RECORD_ID\n1001\n",
+		options:  record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected: `"id":"3001"`,
+	},
+	{
+		name:     "wrong-number-of-fields",
+		csvText:  "DATA_SOURCE,RECORD_ID\nCUSTOMERS,1001,extra\n",
+		options:  record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected: "wrong number of fields",
+	},
+	{
+		name:     "bare-quote",
+		csvText:  "DATA_SOURCE,RECORD_ID\nCUSTOMERS,10\"01\n",
+		options:  record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected: "bare \\\" in non-quoted-field",
+	},
+	{
+		name:     "flatten-conflict",
+		csvText:  "DATA_SOURCE,RECORD_ID,NAME,NAME.FULL\nCUSTOMERS,1001,Robert,Robert Smith\n",
+		options:  record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: &jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""}, LazyQuotes: false, QuoteAll: false},
+		expected: "a value is also a container",
+	},
+}
+
+var testCasesForJSONLinesToCSV = []struct {
+	name      string
+	jsonLines string
+	options   record.CSVOptions
+	expected  string
+}{
+	{
+		name:      "basic",
+		jsonLines: `{"NAME_FULL": "Robert Smith", "RECORD_ID": "1001", "DATA_SOURCE": "CUSTOMERS"}` + "\n\n" + `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1002", "NAME_FULL": "Smith, \"Bob\"", "PHONE_NUMBER": null, "AGE": 42}` + "\n",
+		options:   record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected:  "DATA_SOURCE,RECORD_ID,NAME_FULL,PHONE_NUMBER,AGE\nCUSTOMERS,1001,Robert Smith,,\nCUSTOMERS,1002,\"Smith, \"\"Bob\"\"\",,42\n",
+	},
+	{
+		name:      "nested",
+		jsonLines: `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "NAMES": [{"NAME_FULL": "Robert Smith"}]}`,
+		options:   record.CSVOptions{DataSource: "", Delimiter: ';', Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected:  "DATA_SOURCE;RECORD_ID;NAMES\nCUSTOMERS;1001;\"[{\"\"NAME_FULL\"\":\"\"Robert Smith\"\"}]\"\n",
+	},
+	{
+		name:      "flatten",
+		jsonLines: `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "NAMES": [{"NAME_FULL": "Robert Smith"}, {"NAME_FULL": "Bob Smith"}]}`,
+		options:   record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: &jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysIndexed, Separator: ""}, LazyQuotes: false, QuoteAll: false},
+		expected:  "DATA_SOURCE,RECORD_ID,NAMES.0.NAME_FULL,NAMES.1.NAME_FULL\nCUSTOMERS,1001,Robert Smith,Bob Smith\n",
+	},
+	{
+		name:      "quote-all",
+		jsonLines: `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "ACTIVE": true}`,
+		options:   record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: true},
+		expected:  "\"DATA_SOURCE\",\"RECORD_ID\",\"ACTIVE\"\n\"CUSTOMERS\",\"1001\",\"true\"\n",
+	},
+	{
+		name:      "empty",
+		jsonLines: "",
+		options:   record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false},
+		expected:  "DATA_SOURCE,RECORD_ID\n",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test the CSVToJSONLines function.
+func TestCSVToJSONLines(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForCSVToJSONLines {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			var buffer bytes.Buffer
+
+			err := record.CSVToJSONLines(test.Context(), strings.NewReader(testCase.csvText), &buffer, testCase.options)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, buffer.String())
+		})
+	}
+}
+
+func TestCSVToJSONLines_canceled(test *testing.T) {
+	test.Parallel()
+
+	ctx, cancel := context.WithCancel(test.Context())
+	cancel()
+
+	err := record.CSVToJSONLines(ctx, strings.NewReader("DATA_SOURCE,RECORD_ID\nCUSTOMERS,1001\n"), &bytes.Buffer{},
+		record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false})
+	require.ErrorIs(test, err, context.Canceled)
+}
+
+func TestCSVToJSONLines_errors(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForCSVToJSONLinesErrors {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			var buffer bytes.Buffer
+
+			err := record.CSVToJSONLines(test.Context(), strings.NewReader(testCase.csvText), &buffer, testCase.options)
+			require.Error(test, err)
+			assert.Contains(test, err.Error(), testCase.expected)
+			assert.True(test, json.Valid([]byte(err.Error())), err.Error())
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test the JSONLinesToCSV function.
+func TestJSONLinesToCSV(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForJSONLinesToCSV {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			var buffer bytes.Buffer
+
+			err := record.JSONLinesToCSV(test.Context(), strings.NewReader(testCase.jsonLines), &buffer,
+				testCase.options)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, buffer.String())
+		})
+	}
+}
+
+func TestJSONLinesToCSV_invalidRecord(test *testing.T) {
+	test.Parallel()
+
+	jsonLines := `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}` + "\n" + `{"DATA_SOURCE": "CUSTOMERS"}` + "\n"
+
+	err := record.JSONLinesToCSV(test.Context(), strings.NewReader(jsonLines), &bytes.Buffer{},
+		record.CSVOptions{DataSource: "", Delimiter: 0, Flatten: nil, LazyQuotes: false, QuoteAll: false})
+	require.Error(test, err)
+	assert.Contains(test, err.Error(), "line 2")
+	assert.Contains(test, err.Error(), `"id":"3002"`)
+}
+
+func TestJSONLinesToCSV_roundTrip(test *testing.T) {
+	test.Parallel()
+
+	jsonLines := `{"DATA_SOURCE":"CUSTOMERS","NAMES":[{"NAME_FULL":"Robert \"Bob\" Smith"}],"NOTES":"a\nb","RECORD_ID":"1001"}` + "\n"
+	options := record.CSVOptions{
+		DataSource: "",
+		Delimiter:  0,
+		Flatten:    &jsonutil.FlattenOptions{Arrays: jsonutil.FlattenArraysBracketed, Separator: ""},
+		LazyQuotes: false,
+		QuoteAll:   false,
+	}
+
+	var csvBuffer, jsonBuffer bytes.Buffer
+
+	err := record.JSONLinesToCSV(test.Context(), strings.NewReader(jsonLines), &csvBuffer, options)
+	require.NoError(test, err)
+
+	err = record.CSVToJSONLines(test.Context(), &csvBuffer, &jsonBuffer, options)
+	require.NoError(test, err)
+	assert.Equal(test, jsonLines, jsonBuffer.String())
+}
@@ -3,5 +3,7 @@ Package record is used to validate Senzing records.
 
 The Record structure can be used to parse JSON into records.
 There are functions for validating strings and creating Records.
+
+To convert records between CSV (e.g. spreadsheets) and JSON lines, use [CSVToJSONLines] and [JSONLinesToCSV].
 */
 package record
@@ -20,6 +20,8 @@ var IDMessages = map[int]string{
 	3000: "JSON-line not well formed",
 	3001: "a DATA_SOURCE field is required",
 	3002: "a RECORD_ID field is required",
+	3003: "CSV header row is missing",
+	3004: "CSV column names must be unique",
 }
 
 var (
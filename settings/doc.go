@@ -1,4 +1,11 @@
 /*
 Package settings is used to create the JSON needed for Senzing runtime object settings.
+
+Settings written for legacy G2 deployments (including G2Module.ini files) can be identified with [DetectGeneration]
+and migrated with [ConvertToSz].
+
+Setting values may contain secret references such as "${env:DB_PASSWORD}" or "${file:/run/secrets/db}".
+They are resolved by [BuildSimpleSettingsUsingMap] and [VerifySettings], or explicitly with [ResolveSecrets].
+Additional schemes can be added with [RegisterSecretResolver].
 */
 package settings
@@ -0,0 +1,359 @@
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// Legacy G2 installations placed binaries and resources under ".../senzing/g2",
+// current Sz installations use ".../senzing/er".
+const (
+	g2PathSegment = "/senzing/g2"
+	szPathSegment = "/senzing/er"
+)
+
+// Top-level stanzas of Senzing engine configuration. Settings with none of them are not recognized.
+var settingsStanzas = []string{"PIPELINE", "SQL"}
+
+// JSON keys that are only found in legacy G2 settings.
+// They have no meaning in current Sz settings and are removed during conversion.
+var legacyKeys = map[string]bool{
+	"G2CONFIGFILE": true,
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The String method returns a human readable name for the settings generation.
+
+Output
+  - "G2", "Sz", or "unknown".
+*/
+func (generation Generation) String() string {
+	switch generation {
+	case GenerationG2:
+		return "G2"
+	case GenerationSz:
+		return "Sz"
+	case GenerationUnknown:
+		return "unknown"
+	default:
+		return "unknown"
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The ConvertToG2 method rewrites Senzing engine configuration into the legacy G2 layout.
+Paths under ".../senzing/er" are rewritten to ".../senzing/g2", including the paths of database URLs
+(e.g. sqlite3://na:na@/opt/senzing/er/var/sqlite/G2C.db).
+
+ConvertToG2 is not an exact inverse of [ConvertToSz]. Converting legacy G2 settings to Sz and back loses:
+  - Keys that only G2 uses (e.g. SQL.G2CONFIGFILE), as their values cannot be recovered from Sz settings.
+  - The G2Module.ini format, as the result is always JSON.
+  - The order of keys, and whitespace.
+
+File names (e.g. G2C.db, g2SifterRules.ibm, and templates/g2config.json) are the same in both generations,
+so only the directories that hold them are rewritten.
+
+Input
+  - settings: Senzing engine configuration JSON or the contents of a legacy G2Module.ini file.
+
+Output
+  - A string containing the JSON document with legacy G2 paths.
+*/
+func ConvertToG2(settings string) (string, error) {
+	document, _, err := parseSettingsDocument(settings)
+	if err != nil {
+		return "", wraperror.Errorf(err, "parseSettingsDocument")
+	}
+
+	rewriteValue(document, szPathSegment, g2PathSegment)
+
+	result, err := marshalSettingsDocument(document)
+
+	return result, wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+/*
+The ConvertToSz method rewrites legacy G2 engine configuration into the current Sz layout.
+Paths under ".../senzing/g2" (e.g. /opt/senzing/g2) are rewritten to ".../senzing/er" (e.g. /opt/senzing/er),
+including the paths of database URLs (e.g. sqlite3://na:na@/opt/senzing/g2/var/sqlite/G2C.db),
+and keys that are only meaningful to G2 (e.g. SQL.G2CONFIGFILE) are removed.
+See [ConvertToG2] for what cannot be restored.
+Legacy G2Module.ini files are converted to JSON.
+
+Input
+  - settings: Senzing engine configuration JSON or the contents of a legacy G2Module.ini file.
+
+Output
+  - A string containing the JSON document with current Sz paths.
+*/
+func ConvertToSz(settings string) (string, error) {
+	document, _, err := parseSettingsDocument(settings)
+	if err != nil {
+		return "", wraperror.Errorf(err, "parseSettingsDocument")
+	}
+
+	removeLegacyKeys(document)
+	rewriteValue(document, g2PathSegment, szPathSegment)
+
+	result, err := marshalSettingsDocument(document)
+
+	return result, wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+/*
+The DetectGeneration method determines if Senzing engine configuration is legacy G2 or current Sz.
+
+Settings are considered legacy G2 if they are in G2Module.ini format,
+contain G2-only keys (e.g. SQL.G2CONFIGFILE),
+or contain paths under ".../senzing/g2".
+Otherwise, settings with a PIPELINE or SQL stanza are considered current Sz,
+and other JSON objects (e.g. {}) are GenerationUnknown.
+
+Input
+  - settings: Senzing engine configuration JSON or the contents of a legacy G2Module.ini file.
+
+Output
+  - The generation of the settings.
+  - An error if the settings are neither a JSON object nor a G2Module.ini file,
+    including a JSON object followed by other text.
+*/
+func DetectGeneration(settings string) (Generation, error) {
+	document, fromINI, err := parseSettingsDocument(settings)
+	if err != nil {
+		return GenerationUnknown, wraperror.Errorf(err, "parseSettingsDocument")
+	}
+
+	if fromINI || hasLegacyMarkers(document) {
+		return GenerationG2, nil
+	}
+
+	for _, stanza := range settingsStanzas {
+		if _, isFound := document[stanza]; isFound {
+			return GenerationSz, nil
+		}
+	}
+
+	return GenerationUnknown, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func hasLegacyMarkers(jsonValue any) bool {
+	switch typedValue := jsonValue.(type) {
+	case map[string]any:
+		for key, value := range typedValue {
+			if legacyKeys[key] || hasLegacyMarkers(value) {
+				return true
+			}
+		}
+	case []any:
+		for _, value := range typedValue {
+			if hasLegacyMarkers(value) {
+				return true
+			}
+		}
+	case string:
+		return rewritePath(typedValue, g2PathSegment, szPathSegment) != typedValue
+	}
+
+	return false
+}
+
+func isINI(text string) bool {
+	for line := range strings.SplitSeq(text, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		return strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]")
+	}
+
+	return false
+}
+
+func marshalSettingsDocument(document map[string]any) (string, error) {
+	resultBuffer := &bytes.Buffer{}
+	jsonEncoder := json.NewEncoder(resultBuffer)
+	jsonEncoder.SetEscapeHTML(false)
+
+	err := jsonEncoder.Encode(document)
+	if err != nil {
+		return "", wraperror.Errorf(err, "jsonEncoder.Encode")
+	}
+
+	return strings.TrimSuffix(resultBuffer.String(), "\n"), nil
+}
+
+func parseINI(iniText string) (map[string]any, error) {
+	var section map[string]any
+
+	result := map[string]any{}
+
+	for index, line := range strings.Split(iniText, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case len(line) == 0, strings.HasPrefix(line, "#"), strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			sectionName := strings.TrimSpace(line[1 : len(line)-1])
+
+			existingSection, isOK := result[sectionName].(map[string]any)
+			if !isOK {
+				existingSection = map[string]any{}
+				result[sectionName] = existingSection
+			}
+
+			section = existingSection
+		default:
+			key, value, found := strings.Cut(line, "=")
+			if !found || section == nil {
+				return nil, wraperror.Errorf(errForPackage, "malformed INI at line %d: %s", index+1, line)
+			}
+
+			section[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return result, nil
+}
+
+func parseSettingsDocument(settings string) (map[string]any, bool, error) {
+	var document map[string]any
+
+	if !json.Valid([]byte(settings)) && isINI(settings) {
+		iniDocument, err := parseINI(settings)
+
+		return iniDocument, true, wraperror.Errorf(err, "parseINI")
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(settings))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&document)
+	if err != nil {
+		return nil, false, wraperror.Errorf(err, "Decode")
+	}
+
+	if len(strings.TrimSpace(settings[decoder.InputOffset():])) > 0 {
+		return nil, false, wraperror.Errorf(errForPackage, "settings have text after the JSON object: %s", settings)
+	}
+
+	if document == nil {
+		return nil, false, wraperror.Errorf(errForPackage, "settings are not a JSON object: %s", settings)
+	}
+
+	return document, false, nil
+}
+
+func removeLegacyKeys(jsonValue any) {
+	switch typedValue := jsonValue.(type) {
+	case map[string]any:
+		for key, value := range typedValue {
+			if legacyKeys[key] {
+				delete(typedValue, key)
+			} else {
+				removeLegacyKeys(value)
+			}
+		}
+	case []any:
+		for _, value := range typedValue {
+			removeLegacyKeys(value)
+		}
+	}
+}
+
+/*
+Replace path segments such as "/senzing/g2" when they are followed by a path separator or the end of the string.
+In URIs (e.g. database connections), only the path is rewritten, not the scheme, user, password, or host.
+*/
+func rewritePath(path string, fromSegment string, toSegment string) string {
+	if index := strings.Index(path, "://"); index >= 0 {
+		return rewriteURIPath(path, index+len("://"), fromSegment, toSegment)
+	}
+
+	var result strings.Builder
+
+	remainder := path
+
+	for {
+		index := strings.Index(remainder, fromSegment)
+		if index < 0 {
+			break
+		}
+
+		end := index + len(fromSegment)
+		if end == len(remainder) || remainder[end] == '/' {
+			result.WriteString(remainder[:index])
+			result.WriteString(toSegment)
+		} else {
+			result.WriteString(remainder[:end])
+		}
+
+		remainder = remainder[end:]
+	}
+
+	result.WriteString(remainder)
+
+	return result.String()
+}
+
+/*
+Rewrite the path of a URI (e.g. "/opt/senzing/g2/sqlite/G2C.db" in "sqlite3://na:na@/opt/senzing/g2/sqlite/G2C.db").
+The path starts at the first "/" after the user information, if any, or else after the scheme.
+User information ends at the last "@" before the query, as a password may hold "/".
+*/
+func rewriteURIPath(uri string, authorityStart int, fromSegment string, toSegment string) string {
+	pathStart := authorityStart
+
+	beforeQuery, _, _ := strings.Cut(uri[authorityStart:], "?")
+	if index := strings.LastIndex(beforeQuery, "@"); index >= 0 {
+		pathStart += index + 1
+	}
+
+	index := strings.Index(uri[pathStart:], "/")
+	if index < 0 {
+		return uri
+	}
+
+	pathStart += index
+
+	return uri[:pathStart] + rewritePath(uri[pathStart:], fromSegment, toSegment)
+}
+
+func rewriteValue(jsonValue any, fromSegment string, toSegment string) {
+	switch typedValue := jsonValue.(type) {
+	case map[string]any:
+		for key, value := range typedValue {
+			stringValue, isString := value.(string)
+			if isString {
+				typedValue[key] = rewritePath(stringValue, fromSegment, toSegment)
+			} else {
+				rewriteValue(value, fromSegment, toSegment)
+			}
+		}
+	case []any:
+		for index, value := range typedValue {
+			stringValue, isString := value.(string)
+			if isString {
+				typedValue[index] = rewritePath(stringValue, fromSegment, toSegment)
+			} else {
+				rewriteValue(value, fromSegment, toSegment)
+			}
+		}
+	}
+}
@@ -1,7 +1,10 @@
 // The settings package helps configure SENZING_ENGINE_CONFIGURATION_JSON.
 package settings
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 // ----------------------------------------------------------------------------
 // Types
@@ -23,6 +26,18 @@ type SzConfiguration struct {
 	SQL      SzConfigurationSQL      `json:"SQL"`
 }
 
+// A SecretResolver returns the secret identified by the "reference" part of a "${scheme:reference}" secret reference.
+// Implementations must not log the secrets they return.
+type SecretResolver interface {
+	ResolveSecret(ctx context.Context, reference string) (string, error)
+}
+
+// SecretResolverFunc adapts an ordinary function to the SecretResolver interface.
+type SecretResolverFunc func(ctx context.Context, reference string) (string, error)
+
+// Generation identifies the era of a Senzing engine configuration.
+type Generation int
+
 // ----------------------------------------------------------------------------
 // Constants
 // ----------------------------------------------------------------------------
@@ -30,6 +45,13 @@ type SzConfiguration struct {
 // Identfier of the  package found messages having the format "SZSDK6402xxxx".
 const ComponentID = 6402
 
+// Settings generations.
+const (
+	GenerationUnknown Generation = iota // Not recognized as Senzing engine configuration.
+	GenerationG2                        // Legacy G2 settings, including G2Module.ini files.
+	GenerationSz                        // Current Sz settings.
+)
+
 // ----------------------------------------------------------------------------
 // Variables
 // ----------------------------------------------------------------------------
@@ -0,0 +1,256 @@
+package settings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/senzing-garage/go-helpers/settingsparser"
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+const redactedSecret = "xxxxx"
+
+// Matches secret references like "${env:DB_PASSWORD}" or "${file:/run/secrets/db}".
+var secretReferencePattern = regexp.MustCompile(settingsparser.SecretReferenceRegexp)
+
+var (
+	secretResolvers = map[string]SecretResolver{
+		"env":  SecretResolverFunc(resolveEnvSecret),
+		"file": SecretResolverFunc(resolveFileSecret),
+	}
+	secretResolversMutex sync.RWMutex
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// scrubbedError has a message with resolved secrets removed, but still unwraps to the original error.
+type scrubbedError struct {
+	err     error
+	message string
+}
+
+func (scrubbed *scrubbedError) Error() string {
+	return scrubbed.message
+}
+
+func (scrubbed *scrubbedError) Unwrap() error {
+	return scrubbed.err
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The ResolveSecret method calls the underlying function.
+
+Input
+  - ctx: A context to control lifecycle.
+  - reference: The "reference" part of "${scheme:reference}".
+
+Output
+  - The secret.
+*/
+func (resolverFunc SecretResolverFunc) ResolveSecret(ctx context.Context, reference string) (string, error) {
+	return resolverFunc(ctx, reference)
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The HasSecretReferences method reports whether the text contains "${scheme:reference}" secret references.
+
+Input
+  - text: The text to inspect. Usually Senzing engine configuration JSON.
+
+Output
+  - true if at least one secret reference is found.
+*/
+func HasSecretReferences(text string) bool {
+	return secretReferencePattern.MatchString(text)
+}
+
+/*
+The RegisterSecretResolver method adds, replaces, or removes the resolver for a secret reference scheme.
+The "env" and "file" schemes are registered by default:
+
+	${env:DB_PASSWORD}       The value of the DB_PASSWORD environment variable.
+	${file:/run/secrets/db}  The contents of /run/secrets/db, without trailing newlines.
+
+Input
+  - scheme: The "scheme" part of "${scheme:reference}".
+  - resolver: The resolver for the scheme. If nil, the scheme is unregistered.
+*/
+func RegisterSecretResolver(scheme string, resolver SecretResolver) {
+	secretResolversMutex.Lock()
+	defer secretResolversMutex.Unlock()
+
+	if resolver == nil {
+		delete(secretResolvers, scheme)
+
+		return
+	}
+
+	secretResolvers[scheme] = resolver
+}
+
+/*
+The ResolveSecrets method replaces "${scheme:reference}" secret references in Senzing engine configuration JSON
+with the secrets returned by the registered resolvers.
+Resolved secrets are escaped for use inside JSON strings.
+
+Resolved secrets are never part of a returned error.
+Errors only identify the secret reference (e.g. "${env:DB_PASSWORD}").
+
+Input
+  - ctx: A context to control lifecycle.
+  - settings: Senzing engine configuration JSON that may contain secret references.
+
+Output
+  - The Senzing engine configuration JSON with secrets resolved.
+*/
+func ResolveSecrets(ctx context.Context, settings string) (string, error) {
+	result, _, err := resolveSecrets(ctx, settings, escapeJSONString)
+
+	return result, wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func escapeJSONString(value string) string {
+	resultBuffer := &bytes.Buffer{}
+	jsonEncoder := json.NewEncoder(resultBuffer)
+	jsonEncoder.SetEscapeHTML(false)
+
+	err := jsonEncoder.Encode(value)
+	if err != nil {
+		return value
+	}
+
+	result := strings.TrimSuffix(resultBuffer.String(), "\n")
+
+	return result[1 : len(result)-1]
+}
+
+func escapeNothing(value string) string {
+	return value
+}
+
+// Percent-escape a secret for use as one part (e.g. the password) of a database URL.
+func escapeURLComponent(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+func resolveEnvSecret(ctx context.Context, reference string) (string, error) {
+	_ = ctx
+
+	result, isSet := os.LookupEnv(reference)
+	if !isSet {
+		return "", wraperror.Errorf(errForPackage, "environment variable not set: %s", reference)
+	}
+
+	return result, nil
+}
+
+func resolveFileSecret(ctx context.Context, reference string) (string, error) {
+	_ = ctx
+
+	contents, err := os.ReadFile(filepath.Clean(reference))
+	if err != nil {
+		return "", wraperror.Errorf(err, "could not read secret file: %s", reference)
+	}
+
+	return strings.TrimRight(string(contents), "\r\n"), nil
+}
+
+/*
+Replace each secret reference with its resolved (and escaped) value.
+The unescaped resolved values are also returned so they can be scrubbed from subsequent errors.
+*/
+func resolveSecrets(ctx context.Context, text string, escape func(string) string) (string, []string, error) {
+	var (
+		result  strings.Builder
+		secrets []string
+	)
+
+	if !HasSecretReferences(text) {
+		return text, secrets, nil
+	}
+
+	previousEnd := 0
+
+	for _, match := range secretReferencePattern.FindAllStringSubmatchIndex(text, -1) {
+		err := ctx.Err()
+		if err != nil {
+			return "", secrets, wraperror.Errorf(err, "ctx.Err")
+		}
+
+		reference := text[match[0]:match[1]]
+		scheme := text[match[2]:match[3]]
+
+		secretResolversMutex.RLock()
+		resolver, isRegistered := secretResolvers[scheme]
+		secretResolversMutex.RUnlock()
+
+		if !isRegistered {
+			return "", secrets, wraperror.Errorf(errForPackage, "no secret resolver registered for %s", reference)
+		}
+
+		secret, err := resolver.ResolveSecret(ctx, text[match[4]:match[5]])
+		if err != nil {
+			return "", secrets, wraperror.Errorf(scrubSecrets(err, secrets), "could not resolve %s", reference)
+		}
+
+		if len(secret) > 0 {
+			secrets = append(secrets, secret)
+		}
+
+		result.WriteString(text[previousEnd:match[0]])
+		result.WriteString(escape(secret))
+
+		previousEnd = match[1]
+	}
+
+	result.WriteString(text[previousEnd:])
+
+	return result.String(), secrets, nil
+}
+
+/*
+Remove resolved secrets from an error message.
+The original error is kept so errors.Is() and errors.As() still work.
+*/
+func scrubSecrets(err error, secrets []string) error {
+	if err == nil || len(secrets) == 0 {
+		return err
+	}
+
+	message := err.Error()
+	for _, secret := range secrets {
+		message = strings.ReplaceAll(message, secret, redactedSecret)
+		message = strings.ReplaceAll(message, escapeJSONString(secret), redactedSecret)
+		message = strings.ReplaceAll(message, escapeURLComponent(secret), redactedSecret)
+	}
+
+	if message == err.Error() {
+		return err
+	}
+
+	return &scrubbedError{
+		err:     err,
+		message: message,
+	}
+}
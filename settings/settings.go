@@ -108,21 +108,29 @@ In other words, only environment variables will be used.
 
 See BuildSimpleSettingsUsingMap() for information on the environment variables used.
 
+Input
+  - ctx: A context to control lifecycle.
+
 Output
   - A string containing a JSON document use when calling Senzing's Init(...) methods.
     See the example output.
 */
-func BuildSimpleSettingsUsingEnvVars() (string, error) {
+func BuildSimpleSettingsUsingEnvVars(ctx context.Context) (string, error) {
 	attributeMap := map[string]string{}
 
-	return BuildSimpleSettingsUsingMap(attributeMap)
+	return BuildSimpleSettingsUsingMap(ctx, attributeMap)
 }
 
 /*
 The BuildSimpleSettingsUsingMap method returns a JSON document for use with Senzing's Init(...) methods.
 
 If the environment variable SENZING_TOOLS_ENGINE_CONFIGURATION_JSON is set,
-the value of SENZING_TOOLS_ENGINE_CONFIGURATION_JSON will be returned unchanged.
+the value of SENZING_TOOLS_ENGINE_CONFIGURATION_JSON will be returned with only its secret references resolved.
+
+Values, including environment variable values, may contain secret references
+such as "${env:DB_PASSWORD}" or "${file:/run/secrets/db}". See RegisterSecretResolver().
+Secrets in the database URL are percent-escaped, so a reference should stand for one part of the URL
+(e.g. the password), and may hold characters like "@", "/", and ":".
 
 If the SENZING_TOOLS_ENGINE_CONFIGURATION_JSON environment variable is not found,
 the precedence used in calculating the values of the returned JSON are:
@@ -144,6 +152,7 @@ The keys and corresponding environment variables are:
 	supportPath             SENZING_TOOLS_SUPPORT_PATH
 
 Input
+  - ctx: A context to control lifecycle.
   - attributeMap: A mapping of a keys to desired values.
     If key doesn't exist, an environment variable will be used when constructing output JSON.
     If environment variable doesn't exist, a default or calculated value will be used when constructing output JSON.
@@ -152,13 +161,18 @@ Output
   - A string containing a JSON document use when calling Senzing's Init(...) methods.
     See the example output.
 */
-func BuildSimpleSettingsUsingMap(attributeMap map[string]string) (string, error) {
-	var err error
+func BuildSimpleSettingsUsingMap(ctx context.Context, attributeMap map[string]string) (string, error) {
+	err := ctx.Err()
+	if err != nil {
+		return "", wraperror.Errorf(err, "ctx.Err")
+	}
 
 	// If SENZING_TOOLS_ENGINE_CONFIGURATION_JSON is set, use it.
 
 	senzingEngineConfigurationJSON, isSet := os.LookupEnv("SENZING_TOOLS_ENGINE_CONFIGURATION_JSON")
 	if isSet {
+		senzingEngineConfigurationJSON, err = ResolveSecrets(ctx, senzingEngineConfigurationJSON)
+
 		return senzingEngineConfigurationJSON, wraperror.Errorf(err, "os.LookupEnv.1")
 	}
 
@@ -167,14 +181,16 @@ func BuildSimpleSettingsUsingMap(attributeMap map[string]string) (string, error)
 
 	senzingEngineConfigurationJSON, isSet = os.LookupEnv("SENZING_ENGINE_CONFIGURATION_JSON")
 	if isSet {
+		senzingEngineConfigurationJSON, err = ResolveSecrets(ctx, senzingEngineConfigurationJSON)
+
 		return senzingEngineConfigurationJSON, wraperror.Errorf(err, "os.LookupEnv.2")
 	}
 
 	// If SENZING_PATH is set, use it.
 
-	err = buildAttributeMap(attributeMap)
+	secrets, err := buildAttributeMap(ctx, attributeMap)
 	if err != nil {
-		return senzingEngineConfigurationJSON, wraperror.Errorf(err, "buildAttributeMap")
+		return senzingEngineConfigurationJSON, wraperror.Errorf(scrubSecrets(err, secrets), "buildAttributeMap")
 	}
 
 	// Construct structure.
@@ -218,81 +234,32 @@ Errors are documented at https://garage.senzing.com/go-helpers/errors.
 Input
 
   - ctx: A context to control lifecycle.
+    Verification stops with an error wrapping ctx.Err() if ctx is canceled or its deadline passes.
 
   - settings: A JSON string. See https://github.com/senzing-garage/knowledge-base/blob/main/lists/environment-variables.md#senzing_tools_engine_configuration_json
+    Secret references (e.g. "${env:DB_PASSWORD}") are resolved before verification.
+    Resolved secrets are not included in returned errors.
 */
 func VerifySettings(ctx context.Context, settings string) error {
-	var err error
-
-	parser := settingsparser.BasicSettingsParser{
-		Settings: settings,
-	}
-
-	// Check database URLs.
-
-	databaseURIs, err := parser.GetDatabaseURIs(ctx)
+	resolvedSettings, secrets, err := resolveSecrets(ctx, settings, escapeJSONString)
 	if err != nil {
-		return wraperror.Errorf(err, "GetDatabaseURIs")
+		return wraperror.Errorf(err, "resolveSecrets")
 	}
 
-	for _, value := range databaseURIs {
-		if len(value) == 0 {
-			return wraperror.Errorf(
-				errForPackage,
-				"SQL.CONNECTION empty in Senzing engine configuration JSON. For more information, visit https://garage.senzing.com/go-helpers/errors",
-			)
-		}
-	}
-
-	// Check Config path.
-
-	configPath, err := parser.GetConfigPath(ctx)
-	if err != nil {
-		return wraperror.Errorf(err, "GetConfigPath")
-	}
-
-	err = checkConfigPath(configPath)
-	if err != nil {
-		return wraperror.Errorf(err, "checkConfigPath")
-	}
+	err = verifyResolvedSettings(ctx, resolvedSettings)
 
-	// Check Resource path.
-
-	resourcePath, err := parser.GetResourcePath(ctx)
-	if err != nil {
-		return wraperror.Errorf(err, "GetResourcePath")
-	}
-
-	err = checkResourcePath(resourcePath)
-	if err != nil {
-		return wraperror.Errorf(err, "checkResourcePath")
-	}
-
-	// Check Support path.
-
-	supportPath, err := parser.GetSupportPath(ctx)
-	if err != nil {
-		return wraperror.Errorf(err, "GetSupportPath")
-	}
-
-	err = checkSupportPath(supportPath)
-	if err != nil {
-		return wraperror.Errorf(err, "checkResourcePath")
-	}
-
-	// Os / Arch specific calls
-
-	err = verifySettings(ctx, settings)
-
-	return err
+	return scrubSecrets(err, secrets)
 }
 
 // ----------------------------------------------------------------------------
 // Private functions
 // ----------------------------------------------------------------------------
 
-func buildAttributeMap(attributeMap map[string]string) error {
-	var err error
+func buildAttributeMap(ctx context.Context, attributeMap map[string]string) ([]string, error) {
+	var (
+		err     error
+		secrets []string
+	)
 
 	senzingPath, isSet := os.LookupEnv("SENZING_PATH")
 	if isSet {
@@ -305,13 +272,18 @@ func buildAttributeMap(attributeMap map[string]string) error {
 	if !inMap {
 		senzingDatabaseURL, err = getOsEnv("SENZING_TOOLS_DATABASE_URL")
 		if err != nil {
-			return wraperror.Errorf(err, "getOsEnv")
+			return secrets, wraperror.Errorf(err, "getOsEnv")
 		}
 	}
 
+	senzingDatabaseURL, secrets, err = resolveSecrets(ctx, senzingDatabaseURL, escapeURLComponent)
+	if err != nil {
+		return secrets, wraperror.Errorf(err, "resolveSecrets")
+	}
+
 	senzingDatabaseURI, err := BuildSenzingDatabaseURI(senzingDatabaseURL)
 	if err != nil {
-		return wraperror.Errorf(err, "BuildSenzingDatabaseURI")
+		return secrets, wraperror.Errorf(err, "BuildSenzingDatabaseURI")
 	}
 
 	attributeMap["databaseURL"] = senzingDatabaseURI
@@ -338,7 +310,24 @@ func buildAttributeMap(attributeMap map[string]string) error {
 		}
 	}
 
-	return err
+	// Resolve secret references in the remaining values.
+
+	for mapKey, value := range attributeMap {
+		if mapKey == "databaseURL" {
+			continue
+		}
+
+		resolvedValue, resolvedSecrets, err := resolveSecrets(ctx, value, escapeNothing)
+		secrets = append(secrets, resolvedSecrets...)
+
+		if err != nil {
+			return secrets, wraperror.Errorf(err, "resolveSecrets")
+		}
+
+		attributeMap[mapKey] = resolvedValue
+	}
+
+	return secrets, err
 }
 
 func buildStruct(attributeMap map[string]string) SzConfiguration {
@@ -623,7 +612,7 @@ func buildURLForSqlite3(databaseURI string) (string, error) {
 	return databaseURI, wraperror.Errorf(err, wraperror.NoMessage)
 }
 
-func checkConfigPath(configPath string) error {
+func checkConfigPath(ctx context.Context, configPath string) error {
 	var err error
 
 	configFiles := []string{
@@ -631,6 +620,10 @@ func checkConfigPath(configPath string) error {
 		"defaultGNRCP.config",
 	}
 	for _, configFile := range configFiles {
+		if err := ctx.Err(); err != nil {
+			return wraperror.Errorf(err, "ctx.Err")
+		}
+
 		targetFile := fmt.Sprintf("%s/%s", configPath, configFile)
 		if _, err := os.Stat(targetFile); err != nil {
 			return wraperror.Errorf(
@@ -644,13 +637,17 @@ func checkConfigPath(configPath string) error {
 	return err
 }
 
-func checkResourcePath(resourcePath string) error {
+func checkResourcePath(ctx context.Context, resourcePath string) error {
 	var err error
 
 	resourceFiles := []string{
 		"templates/g2config.json",
 	}
 	for _, resourceFile := range resourceFiles {
+		if err := ctx.Err(); err != nil {
+			return wraperror.Errorf(err, "ctx.Err")
+		}
+
 		targetFile := fmt.Sprintf("%s/%s", resourcePath, resourceFile)
 		if _, err := os.Stat(targetFile); err != nil {
 			return wraperror.Errorf(
@@ -664,7 +661,7 @@ func checkResourcePath(resourcePath string) error {
 	return err
 }
 
-func checkSupportPath(supportPath string) error {
+func checkSupportPath(ctx context.Context, supportPath string) error {
 	var err error
 
 	supportFiles := []string{
@@ -672,6 +669,10 @@ func checkSupportPath(supportPath string) error {
 		"szBuildVersion.json",
 	}
 	for _, supportFile := range supportFiles {
+		if err := ctx.Err(); err != nil {
+			return wraperror.Errorf(err, "ctx.Err")
+		}
+
 		targetFile := fmt.Sprintf("%s/%s", supportPath, supportFile)
 		if _, err := os.Stat(targetFile); err != nil {
 			return wraperror.Errorf(
@@ -736,3 +737,74 @@ func mapWithDefault(aMap map[string]string, key string, defaultValue string) str
 
 	return defaultValue
 }
+
+func verifyResolvedSettings(ctx context.Context, settings string) error {
+	var err error
+
+	parser := settingsparser.BasicSettingsParser{
+		Settings: settings,
+	}
+
+	// Check database URLs.
+
+	databaseURIs, err := parser.GetDatabaseURIs(ctx)
+	if err != nil {
+		return wraperror.Errorf(err, "GetDatabaseURIs")
+	}
+
+	for _, value := range databaseURIs {
+		if len(value) == 0 {
+			return wraperror.Errorf(
+				errForPackage,
+				"SQL.CONNECTION empty in Senzing engine configuration JSON. For more information, visit https://garage.senzing.com/go-helpers/errors",
+			)
+		}
+	}
+
+	// Check Config path.
+
+	configPath, err := parser.GetConfigPath(ctx)
+	if err != nil {
+		return wraperror.Errorf(err, "GetConfigPath")
+	}
+
+	err = checkConfigPath(ctx, configPath)
+	if err != nil {
+		return wraperror.Errorf(err, "checkConfigPath")
+	}
+
+	// Check Resource path.
+
+	resourcePath, err := parser.GetResourcePath(ctx)
+	if err != nil {
+		return wraperror.Errorf(err, "GetResourcePath")
+	}
+
+	err = checkResourcePath(ctx, resourcePath)
+	if err != nil {
+		return wraperror.Errorf(err, "checkResourcePath")
+	}
+
+	// Check Support path.
+
+	supportPath, err := parser.GetSupportPath(ctx)
+	if err != nil {
+		return wraperror.Errorf(err, "GetSupportPath")
+	}
+
+	err = checkSupportPath(ctx, supportPath)
+	if err != nil {
+		return wraperror.Errorf(err, "checkResourcePath")
+	}
+
+	// Os / Arch specific calls
+
+	err = ctx.Err()
+	if err != nil {
+		return wraperror.Errorf(err, "ctx.Err")
+	}
+
+	err = verifySettings(ctx, settings)
+
+	return err
+}
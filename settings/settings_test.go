@@ -1,8 +1,10 @@
 package settings_test
 
 import (
+	"context"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/senzing-garage/go-helpers/settings"
 	"github.com/senzing-garage/go-helpers/settingsparser"
@@ -140,39 +142,43 @@ func TestBuildSenzingDatabaseURL(test *testing.T) {
 
 func TestBuildSimpleSettingsUsingEnvVars(test *testing.T) {
 	test.Parallel()
+	ctx := test.Context()
 
-	_, err := settings.BuildSimpleSettingsUsingEnvVars()
+	_, err := settings.BuildSimpleSettingsUsingEnvVars(ctx)
 	require.NoError(test, err)
 }
 
 func TestBuildSimpleSettingsUsingMap(test *testing.T) {
 	test.Parallel()
+	ctx := test.Context()
 
 	for _, testCase := range testCases {
 		test.Run(testCase.name, func(test *testing.T) {
 			test.Parallel()
 
 			aMap := buildMap(testCase)
-			_, err := settings.BuildSimpleSettingsUsingMap(aMap)
+			_, err := settings.BuildSimpleSettingsUsingMap(ctx, aMap)
 			require.NoError(test, err)
 		})
 	}
 }
 
 func TestBuildSimpleSettingsUsingMap_using_SENZING_TOOLS_ENGINE_CONFIGURATION_JSON(test *testing.T) {
+	ctx := test.Context()
 	expected := "test value"
 	test.Setenv("SENZING_TOOLS_ENGINE_CONFIGURATION_JSON", expected)
 
-	actual, err := settings.BuildSimpleSettingsUsingMap(map[string]string{})
+	actual, err := settings.BuildSimpleSettingsUsingMap(ctx, map[string]string{})
 	require.NoError(test, err)
 	assert.Equal(test, expected, actual)
 }
 
 func TestBuildSimpleSettingsUsingMap_using_SENZING_ENGINE_CONFIGURATION_JSON(test *testing.T) {
+	ctx := test.Context()
 	expected := "test value"
 	test.Setenv("SENZING_ENGINE_CONFIGURATION_JSON", expected)
 
-	actual, err := settings.BuildSimpleSettingsUsingMap(map[string]string{})
+	actual, err := settings.BuildSimpleSettingsUsingMap(ctx, map[string]string{})
 	require.NoError(test, err)
 	assert.Equal(test, expected, actual)
 }
@@ -182,7 +188,7 @@ func TestBuildSimpleSettingsUsingMap_using_SENZING_TOOLS_LICENSE_STRING_BASE64(t
 	expected := "A1B2C3D4"
 	test.Setenv("SENZING_TOOLS_LICENSE_STRING_BASE64", expected)
 
-	actual, err := settings.BuildSimpleSettingsUsingMap(map[string]string{})
+	actual, err := settings.BuildSimpleSettingsUsingMap(ctx, map[string]string{})
 	require.NoError(test, err)
 	parsedActual, err := settingsparser.New(actual)
 	require.NoError(test, err)
@@ -201,7 +207,7 @@ func TestBuildSimpleSettingsUsingMap_ParseResult(test *testing.T) {
 				test.Parallel()
 
 				aMap := buildMap(testCase)
-				settings, err := settings.BuildSimpleSettingsUsingMap(aMap)
+				settings, err := settings.BuildSimpleSettingsUsingMap(ctx, aMap)
 				require.NoError(test, err)
 				parsedSettings, err := settingsparser.New(settings)
 				require.NoError(test, err)
@@ -215,6 +221,16 @@ func TestBuildSimpleSettingsUsingMap_ParseResult(test *testing.T) {
 	}
 }
 
+func TestBuildSimpleSettingsUsingMap_canceledContext(test *testing.T) {
+	test.Parallel()
+
+	ctx, cancel := context.WithCancel(test.Context())
+	cancel()
+
+	_, err := settings.BuildSimpleSettingsUsingMap(ctx, map[string]string{})
+	require.ErrorIs(test, err, context.Canceled)
+}
+
 func TestGetSenzingPath(test *testing.T) {
 	test.Parallel()
 
@@ -231,7 +247,7 @@ func TestVerifySettings(test *testing.T) {
 			test.Parallel()
 
 			aMap := buildMap(testCase)
-			testJSON, err := settings.BuildSimpleSettingsUsingMap(aMap)
+			testJSON, err := settings.BuildSimpleSettingsUsingMap(ctx, aMap)
 			require.NoError(test, err)
 			err = settings.VerifySettings(ctx, testJSON)
 			require.NoError(test, err)
@@ -239,6 +255,21 @@ func TestVerifySettings(test *testing.T) {
 	}
 }
 
+func TestVerifySettings_expiredContext(test *testing.T) {
+	test.Parallel()
+
+	testJSON, err := settings.BuildSimpleSettingsUsingMap(test.Context(), map[string]string{
+		"databaseURL": "sqlite3://na:na@/tmp/sqlite/G2C.db",
+	})
+	require.NoError(test, err)
+
+	ctx, cancel := context.WithDeadline(test.Context(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	err = settings.VerifySettings(ctx, testJSON)
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+}
+
 // ----------------------------------------------------------------------------
 // Internal functions
 // ----------------------------------------------------------------------------
@@ -51,6 +51,18 @@ For multiple databases, SQL.BACKEND specifies the JSON key for finding the JSON
 Note that in the example, HYBRID, C1, and C2 variable names are user-specified.
 A user could specify alternative names such as MULTI, DB1, and DB2.
 
+Keys without a dedicated method (e.g. SQL.BACKEND or C1.CLUSTER_SIZE) can be read with
+GetString, GetInt, and GetBool using a dotted JSON path and a default value.
+GetStanzas lists the top-level stanzas, including user-named cluster stanzas.
+These methods are in the PathSettingsParser interface, returned by NewPathSettingsParser.
+
+Settings edited by hand, with comments, trailing commas, or single quotes, can be read by NewWithOptions
+with ParserOptions.Lenient.
+
+Methods that return an error check their context before doing any work.
+If the context has been canceled or its deadline has passed, the returned error wraps ctx.Err(),
+so errors.Is(err, context.Canceled) and errors.Is(err, context.DeadlineExceeded) can be used.
+
 # Documentation
 
   - [G2Engine Configuration and the Senzing API]
@@ -4,7 +4,9 @@ package settingsparser
 import (
 	"context"
 	"errors"
+	"regexp"
 
+	"github.com/senzing-garage/go-helpers/jsonutil"
 	"github.com/senzing-garage/go-helpers/wraperror"
 )
 
@@ -22,6 +24,17 @@ type SettingsParser interface {
 	RedactedJSON(ctx context.Context) (string, error)
 }
 
+// PathSettingsParser is a SettingsParser that also reads keys without a dedicated method, using a dotted JSON path.
+// It is separate from SettingsParser, so that existing implementations of SettingsParser still satisfy it.
+// See NewPathSettingsParser.
+type PathSettingsParser interface {
+	SettingsParser
+	GetBool(ctx context.Context, path string, defaultValue bool) (bool, error)
+	GetInt(ctx context.Context, path string, defaultValue int) (int, error)
+	GetStanzas(ctx context.Context) ([]string, error)
+	GetString(ctx context.Context, path string, defaultValue string) (string, error)
+}
+
 // ----------------------------------------------------------------------------
 // Types - struct
 // ----------------------------------------------------------------------------
@@ -43,6 +56,11 @@ type EngineConfiguration struct {
 	SQL      EngineConfigurationSQL      `json:"SQL"`
 }
 
+// ParserOptions controls how NewWithOptions reads settings.
+type ParserOptions struct {
+	Lenient bool // Accept comments, trailing commas, single quotes, and unquoted keys (see jsonutil.NormalizeLenient).
+}
+
 // ----------------------------------------------------------------------------
 // Constants
 // ----------------------------------------------------------------------------
@@ -50,12 +68,22 @@ type EngineConfiguration struct {
 // Identfier of the  package found messages having the format "SZSDK6401xxxx".
 const ComponentID = 6401
 
+/*
+SecretReferenceRegexp matches secret references like "${env:DB_PASSWORD}".
+Its submatches are the scheme (e.g. "env") and the reference (e.g. "DB_PASSWORD").
+It is the one definition of the syntax, shared with settings.RegisterSecretResolver() and settings.ResolveSecrets().
+*/
+const SecretReferenceRegexp = `\$\{([A-Za-z][A-Za-z0-9_-]*):([^}]*)\}`
+
 // ----------------------------------------------------------------------------
 // Variables
 // ----------------------------------------------------------------------------
 
 var errForPackage = errors.New("settingsparser")
 
+// Matches secret references like "${env:DB_PASSWORD}". See settings.RegisterSecretResolver().
+var secretReferencePattern = regexp.MustCompile(SecretReferenceRegexp)
+
 // ----------------------------------------------------------------------------
 // Constructor  methods
 // ----------------------------------------------------------------------------
@@ -73,3 +101,53 @@ func New(settings string) (SettingsParser, error) {
 
 	return result, err
 }
+
+/*
+The NewWithOptions function returns a SettingsParser, as New does, reading settings as options say.
+With options.Lenient, settings edited by hand (e.g. with comments or trailing commas) are accepted:
+settings that are not strict JSON are converted by jsonutil.NormalizeLenient, and GetSettings returns the result.
+
+Input
+  - settings: The _ENGINE_CONFIGURATION_JSON.
+  - options: How the settings are read.
+
+Output
+  - A SettingsParser for the settings.
+  - An error locating the problem (see jsonutil.SyntaxError), if the settings cannot be read.
+*/
+func NewWithOptions(settings string, options ParserOptions) (SettingsParser, error) {
+	return NewPathSettingsParser(settings, options)
+}
+
+/*
+The NewPathSettingsParser function returns a PathSettingsParser, reading settings as options say (see NewWithOptions).
+Besides the methods of SettingsParser, it has GetString, GetInt, GetBool, and GetStanzas.
+
+Input
+  - settings: The _ENGINE_CONFIGURATION_JSON.
+  - options: How the settings are read.
+
+Output
+  - A PathSettingsParser for the settings.
+  - An error locating the problem (see jsonutil.SyntaxError), if the settings cannot be read.
+*/
+func NewPathSettingsParser(settings string, options ParserOptions) (PathSettingsParser, error) {
+	if options.Lenient && !isJSON(settings) {
+		normalizedSettings, err := jsonutil.NormalizeLenient(settings)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "NormalizeLenient")
+		}
+
+		settings = normalizedSettings
+	}
+
+	if !isJSON(settings) {
+		return nil, wraperror.Errorf(errForPackage, "incorrect JSON syntax in %s", settings)
+	}
+
+	result := &BasicSettingsParser{
+		Settings: settings,
+	}
+
+	return result, nil
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -27,6 +28,39 @@ type BasicSettingsParser struct {
 // Interface methods
 // ----------------------------------------------------------------------------
 
+/*
+The GetBool method returns the boolean value found at a JSON path in _ENGINE_CONFIGURATION_JSON.
+JSON booleans and strings accepted by strconv.ParseBool (e.g. "true", "1", "F") are recognized.
+
+Input
+  - ctx: A context to control lifecycle.
+  - path: A dotted JSON path (e.g. "PIPELINE.LOG_ENABLED").
+  - defaultValue: The value returned if the path does not exist.
+
+Output
+  - The value at the JSON path or defaultValue.
+*/
+func (parser *BasicSettingsParser) GetBool(ctx context.Context, path string, defaultValue bool) (bool, error) {
+	value, found, err := parser.getValue(ctx, path)
+	if err != nil || !found {
+		return defaultValue, wraperror.Errorf(err, "getValue")
+	}
+
+	switch typedValue := value.(type) {
+	case bool:
+		return typedValue, nil
+	case string:
+		result, err := strconv.ParseBool(strings.TrimSpace(typedValue))
+		if err != nil {
+			return defaultValue, wraperror.Errorf(err, "%s is not a boolean", path)
+		}
+
+		return result, nil
+	default:
+		return defaultValue, wraperror.Errorf(errForPackage, "%s is not a boolean: %v", path, value)
+	}
+}
+
 /*
 The GetConfigPath method returns the PIPELINE.CONFIGPATH value of _ENGINE_CONFIGURATION_JSON.
 
@@ -37,10 +71,14 @@ Output
   - A string containing the value of a PIPELINE.CONFIGPATH.
 */
 func (parser *BasicSettingsParser) GetConfigPath(ctx context.Context) (string, error) {
-	_ = ctx
+	err := ctx.Err()
+	if err != nil {
+		return "", wraperror.Errorf(err, "ctx.Err")
+	}
+
 	engineConfiguration := &EngineConfiguration{}
 
-	err := json.Unmarshal([]byte(parser.Settings), &engineConfiguration)
+	err = json.Unmarshal([]byte(parser.Settings), &engineConfiguration)
 	if err != nil {
 		return "", wraperror.Errorf(err, "GetConfigPath")
 	}
@@ -60,13 +98,16 @@ Output
   - A string containing the value of a PIPELINE.CONFIGPATH.
 */
 func (parser *BasicSettingsParser) GetDatabaseURIs(ctx context.Context) ([]string, error) {
-	_ = ctx
-
 	var result []string
 
+	err := ctx.Err()
+	if err != nil {
+		return result, wraperror.Errorf(err, "ctx.Err")
+	}
+
 	engineConfiguration := &EngineConfiguration{}
 
-	err := json.Unmarshal([]byte(parser.Settings), &engineConfiguration)
+	err = json.Unmarshal([]byte(parser.Settings), &engineConfiguration)
 	if err != nil {
 		return result, wraperror.Errorf(err, "GetDatabaseURIs")
 	}
@@ -92,6 +133,43 @@ func (parser *BasicSettingsParser) GetDatabaseURIs(ctx context.Context) ([]strin
 	return result, wraperror.Errorf(err, "")
 }
 
+/*
+The GetInt method returns the integer value found at a JSON path in _ENGINE_CONFIGURATION_JSON.
+JSON numbers and numeric strings (e.g. "CLUSTER_SIZE": "1") are recognized.
+
+Input
+  - ctx: A context to control lifecycle.
+  - path: A dotted JSON path (e.g. "C1.CLUSTER_SIZE").
+  - defaultValue: The value returned if the path does not exist.
+
+Output
+  - The value at the JSON path or defaultValue.
+*/
+func (parser *BasicSettingsParser) GetInt(ctx context.Context, path string, defaultValue int) (int, error) {
+	value, found, err := parser.getValue(ctx, path)
+	if err != nil || !found {
+		return defaultValue, wraperror.Errorf(err, "getValue")
+	}
+
+	var numberText string
+
+	switch typedValue := value.(type) {
+	case json.Number:
+		numberText = typedValue.String()
+	case string:
+		numberText = strings.TrimSpace(typedValue)
+	default:
+		return defaultValue, wraperror.Errorf(errForPackage, "%s is not an integer: %v", path, value)
+	}
+
+	result, err := strconv.Atoi(numberText)
+	if err != nil {
+		return defaultValue, wraperror.Errorf(err, "%s is not an integer", path)
+	}
+
+	return result, nil
+}
+
 /*
 The GetLicenseStringBase64 method returns the PIPELINE.LICENSESTRINGBASE64 value of _ENGINE_CONFIGURATION_JSON.
 
@@ -102,10 +180,14 @@ Output
   - A string containing the value of a PIPELINE.LICENSESTRINGBASE64.
 */
 func (parser *BasicSettingsParser) GetLicenseStringBase64(ctx context.Context) (string, error) {
-	_ = ctx
+	err := ctx.Err()
+	if err != nil {
+		return "", wraperror.Errorf(err, "ctx.Err")
+	}
+
 	engineConfiguration := &EngineConfiguration{}
 
-	err := json.Unmarshal([]byte(parser.Settings), &engineConfiguration)
+	err = json.Unmarshal([]byte(parser.Settings), &engineConfiguration)
 	if err != nil {
 		return "", wraperror.Errorf(err, "Unmarshal")
 	}
@@ -125,10 +207,14 @@ Output
   - A string containing the value of a PIPELINE.RESOURCEPATH.
 */
 func (parser *BasicSettingsParser) GetResourcePath(ctx context.Context) (string, error) {
-	_ = ctx
+	err := ctx.Err()
+	if err != nil {
+		return "", wraperror.Errorf(err, "ctx.Err")
+	}
+
 	engineConfiguration := &EngineConfiguration{}
 
-	err := json.Unmarshal([]byte(parser.Settings), &engineConfiguration)
+	err = json.Unmarshal([]byte(parser.Settings), &engineConfiguration)
 	if err != nil {
 		return "", wraperror.Errorf(err, "Unmarshal")
 	}
@@ -151,6 +237,70 @@ func (parser *BasicSettingsParser) GetSettings(ctx context.Context) string {
 	return parser.Settings
 }
 
+/*
+The GetStanzas method returns the names of the top-level JSON stanzas in _ENGINE_CONFIGURATION_JSON.
+This includes user-named stanzas for multi-database configurations (e.g. "HYBRID", "C1", "C2").
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - A sorted list of stanza names (e.g. ["PIPELINE", "SQL"]).
+*/
+func (parser *BasicSettingsParser) GetStanzas(ctx context.Context) ([]string, error) {
+	var result []string
+
+	err := ctx.Err()
+	if err != nil {
+		return result, wraperror.Errorf(err, "ctx.Err")
+	}
+
+	var dictionary map[string]json.RawMessage
+
+	err = json.Unmarshal([]byte(parser.Settings), &dictionary)
+	if err != nil {
+		return result, wraperror.Errorf(err, "Unmarshal")
+	}
+
+	for key := range dictionary {
+		result = append(result, key)
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
+/*
+The GetString method returns the string value found at a JSON path in _ENGINE_CONFIGURATION_JSON.
+JSON numbers and booleans are returned in their textual form.
+
+Input
+  - ctx: A context to control lifecycle.
+  - path: A dotted JSON path (e.g. "SQL.BACKEND").
+  - defaultValue: The value returned if the path does not exist.
+
+Output
+  - The value at the JSON path or defaultValue.
+*/
+func (parser *BasicSettingsParser) GetString(ctx context.Context, path string, defaultValue string) (string, error) {
+	value, found, err := parser.getValue(ctx, path)
+	if err != nil || !found {
+		return defaultValue, wraperror.Errorf(err, "getValue")
+	}
+
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue, nil
+	case json.Number:
+		return typedValue.String(), nil
+	case bool:
+		return strconv.FormatBool(typedValue), nil
+	default:
+		return defaultValue, wraperror.Errorf(errForPackage, "%s is not a string: %v", path, value)
+	}
+}
+
 /*
 The GetSupportPath method returns the PIPELINE.SUPPORTPATH value of _ENGINE_CONFIGURATION_JSON.
 
@@ -161,10 +311,14 @@ Output
   - A string containing the value of a PIPELINE.SUPPORTPATH.
 */
 func (parser *BasicSettingsParser) GetSupportPath(ctx context.Context) (string, error) {
-	_ = ctx
+	err := ctx.Err()
+	if err != nil {
+		return "", wraperror.Errorf(err, "ctx.Err")
+	}
+
 	engineConfiguration := &EngineConfiguration{}
 
-	err := json.Unmarshal([]byte(parser.Settings), &engineConfiguration)
+	err = json.Unmarshal([]byte(parser.Settings), &engineConfiguration)
 	if err != nil {
 		return "", wraperror.Errorf(err, "Unmarshal")
 	}
@@ -207,6 +361,61 @@ func (parser *BasicSettingsParser) RedactedJSON(ctx context.Context) (string, er
 	return result, wraperror.Errorf(err, wraperror.NoMessage)
 }
 
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+Walk a dotted JSON path (e.g. "PIPELINE.CONFIGPATH").
+Numeric path elements index into JSON arrays.
+The boolean result is false if the path does not exist or its value is JSON null.
+*/
+func (parser *BasicSettingsParser) getValue(ctx context.Context, path string) (any, bool, error) {
+	var document any
+
+	err := ctx.Err()
+	if err != nil {
+		return nil, false, wraperror.Errorf(err, "ctx.Err")
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(parser.Settings))
+	decoder.UseNumber()
+
+	err = decoder.Decode(&document)
+	if err != nil {
+		return nil, false, wraperror.Errorf(err, "Decode")
+	}
+
+	current := document
+
+	for element := range strings.SplitSeq(path, ".") {
+		switch typedValue := current.(type) {
+		case map[string]any:
+			value, isOK := typedValue[element]
+			if !isOK {
+				return nil, false, nil
+			}
+
+			current = value
+		case []any:
+			index, err := strconv.Atoi(element)
+			if err != nil || index < 0 || index >= len(typedValue) {
+				return nil, false, nil //nolint:nilerr
+			}
+
+			current = typedValue[index]
+		default:
+			return nil, false, nil
+		}
+	}
+
+	if current == nil {
+		return nil, false, nil
+	}
+
+	return current, true, nil
+}
+
 // ----------------------------------------------------------------------------
 // Private functions
 // ----------------------------------------------------------------------------
@@ -233,6 +442,14 @@ func isJSON(unknownString string) bool {
 }
 
 func redactURL(aURL string) (string, error) {
+	// Secret references (e.g. "${env:DB_PASSWORD}") are not valid URL syntax,
+	// so they are swapped for placeholders while the URL is parsed.
+
+	secretReferences := secretReferencePattern.FindAllString(aURL, -1)
+	for index, secretReference := range secretReferences {
+		aURL = strings.Replace(aURL, secretReference, secretPlaceholder(index), 1)
+	}
+
 	parsedURL, err := url.Parse(aURL)
 	if err != nil {
 		if strings.HasPrefix(aURL, "postgresql://") {
@@ -246,16 +463,26 @@ func redactURL(aURL string) (string, error) {
 		}
 	}
 
-	return parsedURL.Redacted(), wraperror.Error(err)
-}
+	result := parsedURL.Redacted()
+	for index, secretReference := range secretReferences {
+		result = strings.Replace(result, secretPlaceholder(index), secretReference, 1)
+	}
 
-func getMultiDatabaseURIs(ctx context.Context, settings string, backend string) ([]string, error) {
-	var err error
+	return result, wraperror.Error(err)
+}
 
-	_ = ctx
+func secretPlaceholder(index int) string {
+	return "secretreference" + strconv.Itoa(index)
+}
 
+func getMultiDatabaseURIs(ctx context.Context, settings string, backend string) ([]string, error) {
 	result := []string{}
 
+	err := ctx.Err()
+	if err != nil {
+		return result, wraperror.Errorf(err, "ctx.Err")
+	}
+
 	if (len(backend) > 0) && (backend != "SQL") { //nolint:nestif
 		var dictionary map[string]interface{}
 
@@ -2,7 +2,6 @@ package wraperror
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"regexp"
 	"runtime"
@@ -14,6 +13,25 @@ const (
 	callerSkip = 2
 )
 
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// quotedError has a message that is safe to embed in JSON, but still unwraps to the original error
+// so that errors.Is() and errors.As() work on wrapped errors (e.g. context.Canceled).
+type quotedError struct {
+	err     error
+	message string
+}
+
+func (quoted *quotedError) Error() string {
+	return quoted.message
+}
+
+func (quoted *quotedError) Unwrap() error {
+	return quoted.err
+}
+
 // ----------------------------------------------------------------------------
 // Public functions
 // ----------------------------------------------------------------------------
@@ -184,9 +202,10 @@ func sanitizeErr(err error) error {
 		return err
 	}
 
-	singleQuotedMessage := Quote(errMessage)
-
-	return errors.New(singleQuotedMessage) //nolint
+	return &quotedError{
+		err:     err,
+		message: Quote(errMessage),
+	}
 }
 
 func sanitizeText(format string, messages ...any) string {
@@ -137,6 +137,14 @@ func TestHelpers_Errorf_isError_withVariables(test *testing.T) {
 	require.JSONEq(test, expected, actual.Error())
 }
 
+func TestHelpers_Errorf_isError_unwrap(test *testing.T) {
+	test.Parallel()
+
+	err := function1()
+	actual := wraperror.Errorf(err, "unwrap")
+	require.ErrorIs(test, actual, errForTest)
+}
+
 // ----------------------------------------------------------------------------
 // Private functions
 // ----------------------------------------------------------------------------